@@ -16,6 +16,12 @@ const (
 	SonarRegionSize = 1 << 20
 )
 
+// Exit statuses of the testee when it detects a hang or exceeded memory limit on its own.
+const (
+	HangExitCode     = 66
+	MemLimitExitCode = 67
+)
+
 const (
 	SonarEQL = iota
 	SonarNEQ
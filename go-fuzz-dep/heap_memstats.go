@@ -0,0 +1,19 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// +build gofuzz
+// +build !gofuzz_libfuzzer
+// +build !go1.16
+
+package gofuzzdep
+
+import "runtime"
+
+// heapAlloc returns the size of live and not yet swept heap objects.
+// runtime/metrics is not available before Go 1.16, and runtime.ReadMemStats
+// stops the world, so it is called only by memWatcher.loop.
+func heapAlloc() uint64 {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.HeapAlloc
+}
@@ -0,0 +1,20 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// +build gofuzz
+// +build !gofuzz_libfuzzer
+// +build go1.16
+
+package gofuzzdep
+
+import "runtime/metrics"
+
+var heapSample = []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+
+// heapAlloc returns the size of live and not yet swept heap objects.
+// Unlike runtime.ReadMemStats, it does not stop the world.
+// It is called only by memWatcher.loop.
+func heapAlloc() uint64 {
+	metrics.Read(heapSample)
+	return heapSample[0].Value.Uint64()
+}
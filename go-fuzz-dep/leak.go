@@ -0,0 +1,87 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// +build gofuzz
+// +build !gofuzz_libfuzzer
+
+package gofuzzdep
+
+import (
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// leakChecker detects goroutines that are left running by the fuzz function.
+type leakChecker struct {
+	settle time.Duration   // how long to wait for new goroutines to exit
+	n      int             // number of known goroutines
+	known  map[uint64]bool // ids of known goroutines
+	buf    []byte          // reusable buffer for stack dumps
+}
+
+// newLeakChecker remembers all currently running goroutines as known.
+// Background goroutines started during initialization are not leaks.
+func newLeakChecker(settle time.Duration) *leakChecker {
+	lc := &leakChecker{
+		settle: settle,
+		buf:    make([]byte, 64<<10),
+	}
+	lc.known = make(map[uint64]bool)
+	all := stacks(&lc.buf, true)
+	for len(all) != 0 {
+		var g []byte
+		g, all = nextGoroutine(all)
+		lc.known[goroutineID(g)] = true
+	}
+	lc.n = len(lc.known)
+	return lc
+}
+
+// check is called after every execution and crashes the process
+// if the fuzz function leaves new goroutines running.
+// Goroutines are compared by ids rather than by count, so that a leaked goroutine
+// is not masked by a background goroutine that exits at the same time.
+// The count is used only to wait for new goroutines to exit before comparing ids.
+func (lc *leakChecker) check() {
+	// Give new goroutines some time to exit.
+	deadline := time.Now().Add(lc.settle)
+	for runtime.NumGoroutine() > lc.n && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	for {
+		leaked := lc.update()
+		if len(leaked) == 0 {
+			return
+		}
+		if time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+			continue
+		}
+		print("goroutine leak: ", len(leaked), " new goroutines did not exit in ", lc.settle.String(), "\n\n")
+		for _, g := range leaked {
+			print(string(g), "\n\n")
+		}
+		syscall.Exit(2)
+	}
+}
+
+// update forgets known goroutines that have exited and returns stacks of unknown goroutines.
+// Unknown goroutines are never added to the known set.
+func (lc *leakChecker) update() (leaked [][]byte) {
+	alive := make(map[uint64]bool)
+	all := stacks(&lc.buf, true)
+	for len(all) != 0 {
+		var g []byte
+		g, all = nextGoroutine(all)
+		id := goroutineID(g)
+		if lc.known[id] {
+			alive[id] = true
+		} else {
+			leaked = append(leaked, g)
+		}
+	}
+	lc.known = alive
+	lc.n = len(alive)
+	return leaked
+}
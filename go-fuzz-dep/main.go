@@ -22,6 +22,21 @@ func Main(fns []func([]byte) int) {
 	input := mem[CoverSize : CoverSize+MaxInputSize]
 	sonarRegion = mem[CoverSize+MaxInputSize:]
 	runtime.GOMAXPROCS(1) // makes coverage more deterministic, we parallelize on higher level
+	// ReadMemStats stops the world, so measure allocations only when asked to.
+	measureAlloc := getenv("GO_FUZZ_ALLOC") != ""
+	var memStats runtime.MemStats
+	var wd *watchdog
+	if timeout := getenvUint("GO_FUZZ_TIMEOUT"); timeout != 0 {
+		wd = newWatchdog(time.Duration(timeout))
+	}
+	var mw *memWatcher
+	if limit := getenvUint("GO_FUZZ_MEMLIMIT"); limit != 0 {
+		mw = newMemWatcher(limit)
+	}
+	var leaks *leakChecker
+	if settle := getenvUint("GO_FUZZ_LEAKS"); settle != 0 {
+		leaks = newLeakChecker(time.Duration(settle))
+	}
 	for {
 		fnidx, n := read(inFD)
 		if n > uint64(len(input)) {
@@ -32,11 +47,50 @@ func Main(fns []func([]byte) int) {
 			CoverTab[i] = 0
 		}
 		atomic.StoreUint32(&sonarPos, 0)
+		var alloc uint64
+		if measureAlloc {
+			runtime.ReadMemStats(&memStats)
+			alloc = memStats.TotalAlloc
+		}
+		if mw != nil {
+			mw.begin()
+		}
+		if wd != nil {
+			wd.begin()
+		}
 		t0 := time.Now()
 		res := fns[fnidx](input[:n:n])
 		ns := time.Since(t0)
-		write(outFD, uint64(res), uint64(ns), uint64(atomic.LoadUint32(&sonarPos)))
+		if wd != nil {
+			wd.end()
+		}
+		if mw != nil {
+			mw.end()
+		}
+		if measureAlloc {
+			runtime.ReadMemStats(&memStats)
+			alloc = memStats.TotalAlloc - alloc
+		}
+		if leaks != nil {
+			leaks.check()
+		}
+		write(outFD, uint64(res), uint64(ns), uint64(atomic.LoadUint32(&sonarPos)), alloc)
+	}
+}
+
+// getenv returns value of the environment variable name.
+func getenv(name string) string {
+	v, _ := syscall.Getenv(name)
+	return v
+}
+
+// getenvUint returns value of the integer environment variable name.
+func getenvUint(name string) uint64 {
+	var x uint64
+	for _, c := range []byte(getenv(name)) {
+		x = x*10 + uint64(c-'0')
 	}
+	return x
 }
 
 // read reads little-endian-encoded uint8+uint64 from fd.
@@ -62,7 +116,7 @@ func read(fd FD) (uint8, uint64) {
 
 // write writes little-endian-encoded vals... to fd.
 func write(fd FD, vals ...uint64) {
-	var tmp [3 * 8]byte
+	var tmp [4 * 8]byte
 	buf := tmp[:len(vals)*8]
 	for i, v := range vals {
 		serialize64(buf[i*8:], v)
@@ -0,0 +1,88 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// +build gofuzz
+// +build !gofuzz_libfuzzer
+
+package gofuzzdep
+
+import (
+	"runtime"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	. "github.com/dvyukov/go-fuzz/go-fuzz-defs"
+)
+
+// memPollPeriod is how often memory consumption of the fuzz function is checked.
+const memPollPeriod = 10 * time.Millisecond
+
+// memWatcher dumps goroutine stacks and exits if the heap grows by more than
+// the limit during a single execution of the fuzz function. Memory allocated
+// by previous executions is mostly not counted, so the input that allocates is
+// blamed rather than the one that happens to cross the limit.
+// The heap is sampled only by the poller, not on every execution: heap size
+// at the start of an execution is the smaller of the samples taken before and
+// after it started, so only allocations made by previous executions shortly
+// before it started are attributed to it.
+type memWatcher struct {
+	limit uint64
+	execs uint64 // number of begin and end calls, odd while the fuzz function runs
+	fuzzG uint64 // id of the goroutine that runs the fuzz function
+	buf   []byte // buffer for stack dumps
+}
+
+// newMemWatcher must be called from the goroutine that runs the fuzz function.
+func newMemWatcher(limit uint64) *memWatcher {
+	mw := &memWatcher{
+		limit: limit,
+		buf:   make([]byte, 64<<10),
+	}
+	mw.fuzzG = goroutineID(stacks(&mw.buf, false))
+	go mw.loop()
+	return mw
+}
+
+func (mw *memWatcher) begin() {
+	atomic.AddUint64(&mw.execs, 1)
+}
+
+func (mw *memWatcher) end() {
+	atomic.AddUint64(&mw.execs, 1)
+}
+
+func (mw *memWatcher) loop() {
+	var (
+		exec uint64 // execs value of the execution that start belongs to
+		used uint64
+	)
+	prev := heapAlloc() // heap size at the previous poll
+	start := prev       // heap size at the start of exec
+	for {
+		time.Sleep(memPollPeriod)
+		heap := heapAlloc()
+		cur := atomic.LoadUint64(&mw.execs)
+		if cur != exec {
+			// The execution started after the previous poll.
+			exec, start = cur, prev
+			if heap < start {
+				start = heap
+			}
+		}
+		prev = heap
+		if cur%2 == 0 || heap < start || heap-start <= mw.limit {
+			continue
+		}
+		// Heap includes garbage, check again after collecting it.
+		runtime.GC()
+		heap = heapAlloc()
+		if atomic.LoadUint64(&mw.execs) == exec && heap > start && heap-start > mw.limit {
+			used = heap - start
+			break
+		}
+	}
+	print("out of memory: fuzz function allocated ", used>>20, " MB, limit is ", mw.limit>>20, " MB\n\n")
+	dumpGoroutines(&mw.buf, mw.fuzzG)
+	syscall.Exit(MemLimitExitCode)
+}
@@ -0,0 +1,67 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// +build gofuzz
+// +build !gofuzz_libfuzzer
+
+package gofuzzdep
+
+import (
+	"runtime"
+)
+
+// stacks returns stacks of the current goroutine, or of all goroutines if all is set.
+// buf is grown as necessary.
+func stacks(buf *[]byte, all bool) []byte {
+	for {
+		n := runtime.Stack(*buf, all)
+		if n < len(*buf) {
+			return (*buf)[:n]
+		}
+		*buf = make([]byte, 2*len(*buf))
+	}
+}
+
+// dumpGoroutines prints stacks of all goroutines except the current one,
+// the stack of goroutine first goes first.
+func dumpGoroutines(buf *[]byte, first uint64) {
+	self := goroutineID(stacks(buf, false))
+	all := stacks(buf, true)
+	for pass := 0; pass < 2; pass++ {
+		for rest := all; len(rest) != 0; {
+			var g []byte
+			g, rest = nextGoroutine(rest)
+			id := goroutineID(g)
+			if id == self || (id == first) != (pass == 0) {
+				continue
+			}
+			print(string(g), "\n\n")
+		}
+	}
+}
+
+// nextGoroutine splits stack dump into the first goroutine stack and the rest.
+func nextGoroutine(stacks []byte) (g, rest []byte) {
+	for i := 0; i+1 < len(stacks); i++ {
+		if stacks[i] == '\n' && stacks[i+1] == '\n' {
+			return stacks[:i], stacks[i+2:]
+		}
+	}
+	return stacks, nil
+}
+
+// goroutineID parses goroutine id from the "goroutine 42 [running]:" header.
+func goroutineID(g []byte) uint64 {
+	const prefix = "goroutine "
+	if len(g) < len(prefix) || string(g[:len(prefix)]) != prefix {
+		return 0
+	}
+	var id uint64
+	for _, c := range g[len(prefix):] {
+		if c < '0' || c > '9' {
+			break
+		}
+		id = id*10 + uint64(c-'0')
+	}
+	return id
+}
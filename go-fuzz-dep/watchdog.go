@@ -0,0 +1,58 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// +build gofuzz
+// +build !gofuzz_libfuzzer
+
+package gofuzzdep
+
+import (
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	. "github.com/dvyukov/go-fuzz/go-fuzz-defs"
+)
+
+// watchdog dumps goroutine stacks and exits if the fuzz function runs for too long.
+// This gives more useful hang reports than SIGABRT sent by go-fuzz:
+// all goroutines are dumped and the one running the fuzz function goes first,
+// so that go-fuzz can tell different hangs apart.
+type watchdog struct {
+	timeout time.Duration
+	start   int64  // start time of the current execution, 0 if not running
+	fuzzG   uint64 // id of the goroutine that runs the fuzz function
+	buf     []byte // buffer for stack dumps
+}
+
+// newWatchdog must be called from the goroutine that runs the fuzz function.
+func newWatchdog(timeout time.Duration) *watchdog {
+	wd := &watchdog{
+		timeout: timeout,
+		buf:     make([]byte, 64<<10),
+	}
+	wd.fuzzG = goroutineID(stacks(&wd.buf, false))
+	go wd.loop()
+	return wd
+}
+
+func (wd *watchdog) begin() {
+	atomic.StoreInt64(&wd.start, time.Now().UnixNano())
+}
+
+func (wd *watchdog) end() {
+	atomic.StoreInt64(&wd.start, 0)
+}
+
+func (wd *watchdog) loop() {
+	for {
+		time.Sleep(100 * time.Millisecond)
+		start := atomic.LoadInt64(&wd.start)
+		if start != 0 && time.Now().UnixNano()-start > int64(wd.timeout) {
+			break
+		}
+	}
+	print("hang: fuzz function did not return in ", wd.timeout.String(), "\n\n")
+	dumpGoroutines(&wd.buf, wd.fuzzG)
+	syscall.Exit(HangExitCode)
+}
@@ -0,0 +1,211 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"archive/zip"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Read-only JSON API for fuzzing results:
+//
+//	/api/crashers                      list of crashers
+//	/api/crashers/<name>               crasher input
+//	/api/crashers/<name>/output        crasher output
+//	/api/crashers/<name>/quoted        crasher input as Go string literal
+//	/api/corpus                        list of corpus inputs
+//	/api/corpus/<name>                 corpus input
+//	/api/corpus.zip                    all corpus inputs as zip archive
+//	/api/suppressions                  list of crash suppressions
+//	/api/stats/history                 stats snapshots from workdir/stats.jsonl
+func (c *Coordinator) registerAPI() {
+	http.HandleFunc("/api/crashers", c.apiCrashers)
+	http.HandleFunc("/api/crashers/", c.apiCrasher)
+	http.HandleFunc("/api/corpus", c.apiCorpus)
+	http.HandleFunc("/api/corpus/", c.apiCorpusInput)
+	http.HandleFunc("/api/corpus.zip", c.apiCorpusZip)
+	http.HandleFunc("/api/suppressions", c.apiSuppressions)
+	http.HandleFunc("/api/stats/history", c.apiStatsHistory)
+}
+
+type apiCrasher struct {
+	Name      string
+	Size      int
+	Kind      string `json:",omitempty"`
+	Message   string `json:",omitempty"`
+	Bucket    string `json:",omitempty"` // name of the crash bucket in workdir/buckets
+	FirstSeen time.Time
+	Count     uint64 // hits of the crash
+}
+
+type apiInput struct {
+	Name string
+	Size int
+	Prio uint64 // input depth
+	User bool   // input was added by user
+}
+
+type apiSuppression struct {
+	Name      string
+	Signature string
+}
+
+func (c *Coordinator) apiCrashers(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	byInput := make(map[string]*crashBucket)
+	for _, b := range c.buckets {
+		for _, name := range b.Inputs {
+			byInput[name] = b
+		}
+	}
+	res := []apiCrasher{}
+	for sig, a := range c.crashers.m {
+		cr := apiCrasher{
+			Name: hex.EncodeToString(sig[:]),
+			Size: len(a.data),
+		}
+		if b := byInput[cr.Name]; b != nil {
+			cr.Kind = b.Kind
+			cr.Message = b.Message
+			cr.Bucket = hex.EncodeToString(b.sig[:])
+			cr.FirstSeen = b.FirstSeen
+			cr.Count = b.Count
+		}
+		res = append(res, cr)
+	}
+	c.mu.Unlock()
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	serveJSON(w, res)
+}
+
+func (c *Coordinator) apiCrasher(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/api/crashers/")
+	typ := ""
+	if idx := strings.IndexByte(name, '/'); idx != -1 {
+		name, typ = name[:idx], name[idx+1:]
+	}
+	a, ok := c.findArtifact(c.crashers, name)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	switch typ {
+	case "":
+		serveData(w, name, a.data)
+	case "output", "quoted":
+		data, err := ioutil.ReadFile(filepath.Join(c.crashers.dir, name+"."+typ))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write(data)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (c *Coordinator) apiCorpus(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	res := []apiInput{}
+	for sig, a := range c.corpus.m {
+		res = append(res, apiInput{
+			Name: hex.EncodeToString(sig[:]),
+			Size: len(a.data),
+			Prio: a.meta,
+			User: a.user,
+		})
+	}
+	c.mu.Unlock()
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	serveJSON(w, res)
+}
+
+func (c *Coordinator) apiCorpusInput(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/api/corpus/")
+	a, ok := c.findArtifact(c.corpus, name)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	serveData(w, name, a.data)
+}
+
+func (c *Coordinator) apiCorpusZip(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	corpus := make(map[Sig]Artifact, len(c.corpus.m))
+	for sig, a := range c.corpus.m {
+		corpus[sig] = a // data is immutable, so it is safe to use it without the lock
+	}
+	c.mu.Unlock()
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="corpus.zip"`)
+	zw := zip.NewWriter(w)
+	for sig, a := range corpus {
+		f, err := zw.Create(hex.EncodeToString(sig[:]))
+		if err != nil {
+			return
+		}
+		if _, err := f.Write(a.data); err != nil {
+			return
+		}
+	}
+	zw.Close()
+}
+
+func (c *Coordinator) apiSuppressions(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	res := []apiSuppression{}
+	for sig, a := range c.suppressions.m {
+		res = append(res, apiSuppression{
+			Name:      hex.EncodeToString(sig[:]),
+			Signature: string(a.data),
+		})
+	}
+	c.mu.Unlock()
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	serveJSON(w, res)
+}
+
+// lookupArtifact finds artifact by hex-encoded hash of its data.
+// Must be called with c.mu held.
+func lookupArtifact(ps *PersistentSet, name string) (Artifact, bool) {
+	sig, ok := parseSig(name)
+	if !ok {
+		return Artifact{}, false
+	}
+	a, ok := ps.m[sig]
+	return a, ok
+}
+
+// findArtifact is lookupArtifact that takes c.mu.
+func (c *Coordinator) findArtifact(ps *PersistentSet, name string) (Artifact, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return lookupArtifact(ps, name)
+}
+
+func serveJSON(w http.ResponseWriter, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func serveData(w http.ResponseWriter, name string, data []byte) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	w.Write(data)
+}
@@ -3,8 +3,10 @@
 // assets/bootstrap-theme.min.css (23.357kB)
 // assets/bootstrap.min.css (122.54kB)
 // assets/bootstrap.min.js (36.816kB)
+// assets/coverage.html (4.918kB)
+// assets/crashers.html (3.347kB)
 // assets/jquery.min.js (95.992kB)
-// assets/stats.html (3.868kB)
+// assets/stats.html (7.434kB)
 
 package main
 
@@ -155,7 +157,7 @@ func assetsJqueryMinJs() (*asset, error) {
 	return a, nil
 }
 
-var _assetsStatsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x57\xff\x6f\xdb\xb6\x12\xff\xd9\xfa\x2b\x2e\xea\x03\x2c\xa1\x91\x14\xa7\xe9\x43\x91\xc8\x7e\x7d\x48\xdd\x2d\x43\xbf\x61\xc9\x56\x0c\xeb\x7e\xa0\xc5\xb3\xc5\x46\x22\x35\xf2\x64\xc7\x0d\xf2\xbf\x0f\xa4\x24\x47\x76\x9a\x76\x19\x86\x00\x06\x7c\xbc\xfb\xdc\xf1\x73\xc7\x2f\x47\xa5\x7b\xaf\xde\x9f\x5e\xfc\xf6\x61\x0a\x39\x95\xc5\xc4\x4b\xdb\xbf\x42\xc8\x4b\xd0\x58\x8c\x7d\x43\xeb\x02\x4d\x8e\x48\x3e\xe4\x1a\xe7\x63\x3f\x99\x29\x45\x86\x34\xab\xe2\x52\xc8\x38\x33\xc6\xff\xdb\x1e\x11\xe5\x58\x62\xcf\xcf\x4b\x67\x8a\xaf\x27\x1e\x40\xca\xc5\x12\xb2\x82\x19\x33\xf6\x33\x25\x89\x09\x89\x3a\x9a\x17\xb5\xe0\xbe\xb5\x6f\x23\xb4\x5a\xb5\xda\x5d\xcf\x22\x32\x65\x34\x3a\x04\x2b\x95\xdc\x4a\x25\x13\x72\x03\x06\x48\xf3\x51\x87\xae\xd8\x02\xa3\x1c\x19\x47\xed\x4f\x7e\x50\xf0\xba\xfe\xf2\x25\x4d\xf2\x51\x0f\xbc\x3d\x27\x54\x05\xcb\x30\x57\x05\x47\x6d\x7a\x31\xef\x92\xb8\x32\xd1\x33\xe8\xd8\xf4\xdd\xb6\xbc\x2c\x9b\x23\x10\x7c\xec\xaf\x94\xbe\x74\x31\xd3\x24\x3f\xda\x81\x98\x8a\xc9\x2e\x36\xe1\x15\x45\x65\x4d\xc8\xfd\xc9\xc7\xc6\x27\x4d\x2c\x60\x8b\x4c\xc2\xc5\xf2\xdf\x64\x97\x29\x5d\xd5\x0f\x23\x77\xea\x5c\x1e\x83\x9b\x66\x26\x7f\x68\xe9\x4e\x5b\xa7\x47\xe0\xa7\xd1\x10\xd3\xf4\x30\x7e\x3f\xb7\x4e\xff\x8c\xdf\xd1\x43\xf8\xe1\x15\x66\x0f\x23\x37\xb5\x1e\x8f\xc0\x2c\x53\x4b\x6b\x7b\xd0\xa6\x5b\xa2\x7e\x04\x66\x75\x45\xa2\xc4\x07\x51\xfb\xc5\xb9\x7c\x97\x5b\x3b\xec\xdd\x56\x87\x5d\x34\x53\xcf\x36\x97\xd5\x8f\xc2\x90\xd2\xeb\x34\xc9\x0f\xbf\x7e\x59\x11\x9b\x15\x18\x69\x34\x95\x92\x46\x2c\x71\xfb\xb2\x72\xd6\x2d\x28\x34\x0e\x86\xb4\xa8\x90\xef\xa6\x4d\x76\xde\x6d\x9d\xd5\xea\x5d\x95\x83\xde\xde\x4b\x94\x7f\x1d\xd0\xdd\x0d\xf7\xda\x37\xa7\xf3\x3e\xc4\xed\xf9\xb8\x0f\xd1\x6e\xd2\xfb\x29\xb8\x9d\x72\x9f\xb9\x5b\xad\xbb\xf6\x34\xd9\x4d\xdb\xa2\x5c\x79\x06\x8d\xb7\xeb\x68\x69\xd2\xfc\x5b\x65\x9a\xb8\xe2\xde\x59\xe4\x3b\x83\x8d\xd8\x0a\xdd\x66\xf0\x52\x93\x69\x51\x11\x18\x9d\x8d\xfd\xe4\xf3\x9f\x35\xea\xb5\xeb\xa2\x9f\xdd\xc9\x6d\xac\x93\x1d\xd8\x76\x9f\xde\x46\x7a\x5e\xba\x17\x45\x70\x91\x23\xcc\x55\x51\xa8\x95\x90\x0b\x48\x5d\xeb\x9e\x00\x93\x1c\xda\x50\x13\x98\x15\x2a\xbb\x34\x60\xfb\x13\x30\xad\x6a\xc9\x81\x81\xa9\x30\x13\x73\x91\xc1\xac\x5e\xc0\x4a\x50\x0e\x2b\xc1\x29\xf7\x84\x84\xb3\x29\x8c\x0e\x40\x48\xf8\x28\x24\x57\x2b\x03\x2f\x5c\xbc\x6e\xf4\x21\x57\x12\xe1\x45\x0c\xe7\x88\xc7\x5e\x4e\x54\x1d\x27\xc9\x02\xe9\x96\x6c\xa6\x4a\xab\x20\x21\x17\x91\x5b\x64\xe4\xc9\x13\x53\x57\x95\xd2\x14\x09\x1c\x1d\x44\x6e\x2e\x88\x22\x9b\xb0\x63\xec\xbd\x8c\x56\x38\xbb\x14\x14\x2d\x05\xae\x2c\x10\x00\xae\x1b\x4e\xc7\xc0\x71\x29\x32\x6c\xbc\x4e\xe0\xc6\x7b\x19\x95\xea\x4b\x1f\xf9\x1d\xb0\xd9\xc1\x7e\x0b\xac\x76\xb1\xdf\x00\xef\x22\xbf\x05\x4e\x93\x36\xd3\x6e\x5d\xbc\x24\x81\x53\x55\xad\xb5\x58\xe4\x04\x87\x07\xa3\xa3\xe8\xf0\x60\xf4\x1c\x2e\x56\x82\x08\xf5\x3e\x9c\xc9\x2c\xb6\xa0\x37\x22\x43\x69\x90\x43\x2d\x39\x6a\x78\x7b\x76\x01\x81\x2d\xbb\xb1\x75\x17\x94\xd7\x33\x57\x71\x5a\xcd\xcc\xed\x8e\x49\x66\x85\x9a\x25\x25\x33\x84\x3a\x79\x73\x76\x3a\x7d\x77\x3e\x0d\x3d\x31\x87\x40\xb2\xa5\x58\x30\x52\x3a\xae\x0d\xea\xff\x2f\x50\x52\x5c\x32\xca\xf2\x20\x39\x9b\xbe\x55\x33\x51\xe0\xa7\x64\x74\xf0\x29\x3e\x48\xc2\x10\xae\xbd\xc1\x92\x69\x28\xcd\xaf\x6d\xae\xe7\x36\x0b\x18\x03\x57\x59\x5d\x5a\xdf\x4c\x23\x23\x9c\x16\x68\x47\xc1\xd0\x65\x39\x0c\xbd\xc1\x8e\x4b\xcc\xaa\x0a\x25\x3f\xcd\x45\xc1\x03\x6f\x30\xd8\xf1\xbf\xc0\x2b\x7a\xa7\x38\x5a\xd3\x60\xb8\xb5\x66\xd7\x4d\x45\x59\x4d\x6a\x4f\x94\x56\xc3\x24\xdd\x0c\xbd\xc1\x20\xf4\xec\x6f\x13\xc9\x1d\xaa\x73\x2c\x30\x23\xa5\x83\xa1\x3d\xd2\xc3\x70\x6b\xde\x1d\x4e\xa1\xe7\xd6\xa5\x3b\x51\xfd\x95\x79\x2d\xb4\xa1\x7d\xc8\x72\xb4\x67\x47\xcc\x41\x10\x08\x23\x87\x04\xa2\xac\x9a\x5c\x91\xc3\x1a\x29\x76\x45\xdd\x3b\x27\x2d\xe4\x22\xae\xb4\x22\x45\xeb\x0a\xe3\xb9\xd2\x25\x23\x57\xc0\x7b\x6c\x30\x86\x79\x2d\x33\x12\x4a\x06\x0e\xe7\x2a\xcd\xf4\xc2\xc0\xd8\xfe\xb9\xa4\xcc\x89\x37\x18\x68\xa4\x5a\x4b\xa0\x5c\x98\x58\xa3\x6b\x6e\x41\x72\x1d\x7c\xe2\x4f\xc3\x9b\x64\xb1\x7f\x1b\xc6\xad\xe3\x3e\xc8\xba\x9c\xa1\x6e\x62\x6e\x9c\xd7\x15\xaa\xb9\x0b\xff\x7b\x63\xff\x03\xf6\xc6\x30\xb4\x9b\x6a\x2e\x24\x72\x5b\xd0\xc1\xff\xb6\x00\x56\x73\x0c\x2e\xa8\x15\x2d\x95\x9b\xf0\xc4\x1b\xdc\x9c\x78\x37\x9e\x67\xd9\x6a\xb5\x7a\x5d\xda\x4c\x7c\xdb\x4b\x52\xe2\x93\xeb\x83\x9b\x34\x21\xde\xc8\xa3\x9e\x7c\xd8\x93\x9f\xf5\xe4\xa3\x9e\xfc\xbc\x27\xff\xb7\x95\xed\x6d\xed\x37\xb3\xe1\x92\xce\x55\xad\x33\xbb\xff\x24\xae\x60\xba\x44\xd9\x6a\x02\x3f\x41\x3b\x32\x6e\xe4\x87\x27\xde\x06\x1c\x33\xce\x1d\xf2\x8d\x30\x84\x12\x75\xe0\x57\x42\x2e\xfc\x5e\xdd\x70\xb3\xd1\x39\x23\x06\x63\xf8\xe9\xfc\xfd\xbb\xb8\x62\xda\x60\x80\xb1\xd5\xd9\xb4\xff\x13\xf8\xae\x25\xf8\x61\x5c\x69\xb4\xbb\x2a\x68\xf2\x6f\x17\xd4\x6d\x6b\x46\x2c\x6e\xdb\xe7\x7e\x37\x6e\xba\xe5\xed\xb0\x6d\x8e\x56\xe1\x8f\x12\x1f\x9e\xba\x69\xe3\xae\x25\xbe\x42\xa9\xca\x0d\xda\xb5\xc1\x5e\xa8\x25\xea\xcd\xa8\x69\x72\xde\x20\x0c\x4f\x3c\xc7\xef\x49\xf7\x15\x12\xc6\xf6\xe9\x12\xf4\xe9\x84\x0d\xa2\xfd\x12\xe8\x03\x1a\x7e\x9d\xbd\x7b\x8d\x6f\x21\x5a\x65\x8b\xd9\xbc\x88\x5b\xcc\xbd\x59\xb4\xf8\xe6\x85\xda\x0f\xe8\xb2\xda\x30\xb2\xaf\xc4\x6d\x42\x4b\xd4\xad\xb5\x7d\xa9\xf5\xcd\x4d\xda\xa1\x67\x37\x63\xef\x00\xa7\x49\xd3\xb0\xd3\xa4\xf9\x08\xfe\x2b\x00\x00\xff\xff\x1b\x17\x31\x00\x1c\x0f\x00\x00")
+var _assetsStatsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x59\x7b\x73\xdb\xb6\xb2\xff\x5b\xfc\x14\x1b\x26\x13\x93\x0d\x5f\xb2\x9d\xa6\x63\x53\x6a\xef\x75\xdc\x7b\x73\x27\x6d\x33\xb5\xef\xe9\x9c\x49\xfd\x07\x44\xae\x44\x34\x24\xc1\x03\x40\x92\x5d\x57\xdf\xfd\xcc\x82\x20\x45\xc9\x72\x1e\x9d\xd3\x4e\x32\x11\x80\xfd\xed\x13\x8b\xc5\x82\x49\x9f\xbc\xfe\xe9\xe2\xfa\x9f\xef\x2e\xa1\xd0\x55\x39\x75\x52\xfb\x53\xf2\xfa\x03\x48\x2c\x27\xae\xd2\x77\x25\xaa\x02\x51\xbb\x50\x48\x9c\x4f\xdc\x78\x26\x84\x56\x5a\xb2\x26\xaa\x78\x1d\x65\x4a\xb9\x9f\xcd\x11\xea\x02\x2b\x1c\xf0\x39\xe9\x4c\xe4\x77\x53\x07\x20\xcd\xf9\x0a\xb2\x92\x29\x35\x71\x33\x51\x6b\xc6\x6b\x94\xe1\xbc\x5c\xf2\xdc\x25\xfa\x2e\x42\x8a\xb5\x5d\xdd\xe7\x2c\x43\x55\x85\xe3\x63\xa0\x51\x95\xd3\xa8\x62\xbc\xee\xc1\x00\x69\x31\xee\xd0\x0d\x5b\x60\x58\x20\xcb\x51\xba\xd3\xff\x11\xf0\xfd\xf2\xf7\xdf\xd3\xb8\x18\x0f\xc0\xcb\xb2\x03\xd7\x6c\x05\x35\x5b\x85\x0d\x2f\x4b\x35\x90\x07\x90\x96\xbc\x03\xb1\x4c\xf3\x15\xba\xd3\x94\x75\xbe\xbb\xd3\x2b\xcd\xb4\x4a\x63\x36\x4d\xe3\x92\xef\xf1\x0d\x80\x99\x64\xaa\x40\xa9\x22\xda\x03\x77\x7a\x61\xa7\x9f\xc1\x28\x56\x28\xd9\x02\x3b\x46\x3b\x7d\xc8\x98\xc6\xcb\x72\x30\xdb\x0d\x27\x34\x25\xcb\xb0\x10\x65\x8e\x72\xcf\xbd\xbd\xf8\xde\xaa\xf0\x04\xba\x40\x0f\xd9\x76\xb8\x28\xd0\xa7\xc0\xf3\x89\xbb\x16\xf2\x83\x91\x99\xc6\xc5\xe9\x1e\x44\x35\xac\xee\x64\x6b\xbc\xd5\x61\xb5\xd4\x98\xbb\xd3\x5f\x5a\x9e\x34\x26\xc0\x90\x27\x8d\x73\xbe\xfa\x4f\x5a\x97\x09\xd9\x2c\xbf\xcc\xb8\x0b\xc3\xf2\x77\xd8\x66\x73\xe0\xcb\xac\xeb\x13\xe7\x2f\xb7\x4f\xa2\xd2\x4c\xea\x2f\x8b\xde\xcf\x96\xe9\xcf\xd9\x77\xfa\x25\xf6\xe1\x2d\x66\x5f\x66\xdc\x25\x71\xfc\x0d\x96\x65\x74\x48\xbf\xc8\xb2\x0b\xe2\xf8\x1b\x2c\x5b\x36\x9a\x57\xf8\x45\xa6\xfd\xbf\x61\xf9\xa4\x6d\x76\xba\x9d\x17\xc7\x9d\x34\xb5\x9c\xf5\x75\xf8\xa2\xa0\x94\x4a\xe3\xe2\x78\xea\x1c\x72\x6a\x58\xfa\x0f\x39\xac\xaa\xf0\xeb\xae\xfa\x9f\xb8\xd3\xb4\x38\x6d\x77\x35\x56\x98\x99\x78\xa7\x19\xab\x57\x4c\x19\x6f\x33\x52\x16\xb6\x79\x02\x6b\x9e\xeb\x62\xe2\x9e\x26\x89\x0b\x05\xf2\x45\xa1\x27\xee\x71\x92\x50\x2c\x5a\x96\xe9\x9e\x47\x9f\xa5\xbc\xab\x16\x07\x35\xdb\xea\xf3\x97\xa9\x36\x39\xf3\x88\x66\xca\xc0\xbf\x4a\x71\x5f\x83\x0e\xeb\xb6\xe4\x3f\xa1\xde\x4e\x3f\x95\x44\xff\xcb\x95\x16\xf2\xee\xf1\x2c\xd2\x6c\x56\x62\x28\x51\x35\xa2\x56\xe6\xca\xee\x61\x00\xa9\xa1\xee\x40\xc1\xfc\x1b\x2a\x2d\x79\x83\x5d\x47\xd2\xfd\x49\x35\xe9\xdd\x5d\xa3\x55\xb9\xbf\x44\x8b\xc5\xf6\x72\xd3\xc5\x61\x40\x97\x32\x8f\xd2\xfb\xf0\x3e\x86\xd8\x16\xd9\xc7\x10\xb6\xd2\x3d\x46\xb6\xa9\xf3\x18\xb9\x3b\xf2\x0f\xe9\x69\xbc\xef\x36\xa1\x4c\x78\x46\x66\xa6\x4d\xc7\x97\xc6\xed\xaf\x33\x32\x00\x0a\xee\x83\x4d\x7e\x30\xe9\x87\x76\x60\x7f\x1c\x27\x55\x99\xe4\x8d\x06\x25\xb3\x89\x1b\xff\xf6\xaf\x25\xca\x3b\xd3\x65\xfe\x66\xca\x7f\x4b\x9d\xee\xc1\x76\xfb\xd8\x5d\xa4\xe3\xa4\x4f\xc2\x10\xae\x0b\x84\xb9\x28\x4b\xb1\xe6\xf5\x02\x52\xd3\x0c\x4f\x81\xd5\x39\x58\x51\x53\x98\x95\x22\xfb\xa0\x80\x9a\x1c\x60\x52\x2c\xeb\x1c\x18\xa8\x06\x33\x3e\xe7\x19\xcc\x96\x0b\x58\x73\x5d\xb4\x89\xee\xf0\x1a\xde\x5c\xc2\x38\x01\x5e\xc3\x2f\xbc\xce\xc5\x5a\xc1\x37\x46\x5e\x37\x7b\x57\x88\x1a\xe1\x9b\x08\xae\x10\xcf\x9c\x42\xeb\xe6\x2c\x8e\x17\xa8\xb7\xc6\x66\xa2\xa2\x05\xcd\xeb\x45\x68\x36\x19\xf3\xf8\xa9\x5a\x36\x8d\x90\x3a\xe4\x38\x4e\x42\xa3\x0b\xc2\x90\x1c\x36\x16\x3b\xdf\x85\x6b\x9c\x7d\xe0\x3a\x5c\x71\x5c\x13\x10\x00\xee\x5b\x9b\xce\x20\xc7\x15\xcf\xb0\xe5\x3a\x87\x8d\xf3\x5d\x58\x89\xdf\x87\xc8\x4f\x80\xd5\x1e\xf6\x63\x60\xb1\x8f\xfd\x08\x78\x1f\xf9\x31\x70\x1a\x5b\x4f\xbb\x7d\x71\xe2\x18\x2e\x44\x73\x27\xa9\xa6\xc0\x71\x32\x3e\x0d\x8f\x93\xf1\x4b\xb8\x5e\x73\xad\x51\x06\xf0\xa6\xce\x22\x02\xbd\xe5\x19\xd6\x0a\x73\x58\xd6\x39\x4a\xf8\xe1\xcd\x35\x78\x14\x76\x45\x71\xe7\xba\x58\xce\x4c\xc4\xf5\x7a\xa6\xb6\xef\x98\x78\x56\x8a\x59\x5c\x31\xa5\x51\xc6\x6f\xdf\x5c\x5c\xfe\x78\x75\xe9\x3b\x7c\x0e\x5e\xcd\x56\x7c\xc1\xb4\x90\xd1\x52\xa1\xfc\xaf\x05\xd6\x3a\xaa\x98\xce\x0a\x2f\x7e\x73\xf9\x83\x98\xf1\x12\x7f\x8d\xc7\xc9\xaf\x51\x12\xfb\x3e\xdc\x3b\xa3\x15\x93\x50\xa9\x7f\x58\x5f\xaf\xc8\x0b\x98\x40\x2e\xb2\x65\x45\xbc\x99\x44\xa6\xf1\xb2\x44\x9a\x79\x47\xc6\xcb\x23\xdf\x19\xed\xb1\x44\xac\x69\xb0\xce\x2f\x0a\x5e\xe6\x9e\x33\x1a\xed\xf1\x5f\xe3\xad\xfe\x51\xe4\x48\xa4\xd1\xd1\xce\x9e\xdd\x9b\x7d\x3f\x63\x4b\x2d\x9e\xf0\x8a\x04\xb2\x5a\x6f\x8e\x9c\xd1\xc8\x77\xe8\x6f\x2f\xc9\x1c\xaa\x2b\x2c\x31\xd3\x42\x7a\x47\x54\xf1\x8e\xfc\x1d\xbd\x7b\x36\xf9\xce\xc6\x19\x9c\xa8\xe1\xce\x7c\xcf\xa5\xd2\x01\x64\x05\xd2\xd9\xe1\x73\xe0\x1a\xb8\xaa\x8f\x34\xf0\xaa\x69\x7d\xc5\x1c\xee\x50\x47\x26\xa8\x4f\xae\xb4\xe4\xf5\x22\x6a\xa4\xd0\x42\xdf\x35\x18\xcd\x85\xac\x98\x36\x01\x7c\x84\x06\x13\x98\x2f\xeb\x4c\x73\x51\x7b\x06\x67\x22\xcd\xe4\x42\xc1\x04\x98\x5c\x98\xf0\xaa\x73\x67\x34\x92\xa8\x97\xb2\x06\x5d\x70\x15\x49\x34\x5d\xa5\x17\xdf\x7b\xbf\xe6\x2f\xfc\x4d\xbc\x08\xb6\x62\xcc\x3e\x06\x50\x2f\xab\x19\xca\x56\x66\xcf\x7c\xd7\xa0\x98\x93\x5c\xf5\xbe\xa5\xdf\xc0\x93\x09\x1c\x51\x52\xcd\x79\x8d\x39\x05\x74\xf4\xed\x0e\x80\x56\xce\xc0\x08\xa5\x21\x99\xb2\xf1\xcf\x9d\xd1\xe6\xdc\xd9\x38\x14\x25\x7b\x89\x81\x62\x14\x14\x05\xa5\x60\x39\xe6\x30\x97\xa2\x82\x98\x35\x3c\x56\xf4\xb2\x8c\x0b\x0b\xa3\x32\x82\xb7\x1a\x6b\x02\xcd\xee\xa0\xa1\x9a\x85\x2b\x72\x33\x72\xc8\xf9\x4e\xce\x04\xde\xdf\x9c\x3b\x4e\xe7\x17\xe4\x92\xad\x4d\xd7\xe5\xf1\x3c\x80\x39\xc7\x32\xef\x53\xd3\xde\xdd\x83\x8c\x5c\xa0\xb6\xe9\xf8\xdf\x77\x6f\x72\x8f\xe7\x64\x33\x89\xcf\xf4\x2d\x4c\x2c\x43\xb4\x40\x7d\x21\x6a\xea\x12\x3d\xf7\x38\x77\x3b\xcc\x7a\x8b\x30\x79\x17\x40\xb1\x5d\x69\x1b\x80\x00\x1a\x96\xc3\x04\x4e\x93\x73\x67\x94\xe9\xdb\x28\x2b\x91\xc9\x9f\x31\xd3\x5e\x12\x40\x12\xc0\x3a\x80\x82\xe4\x51\x66\x58\x97\xa2\x12\xeb\x85\x2e\x60\x32\x81\xc4\x98\x6e\xf7\x85\xa2\xd9\x2a\xd6\x09\x4c\xba\x00\xbc\x4f\x6e\x22\x1d\x80\x1e\x0f\x96\xf6\x04\x85\x30\xbe\x89\xb4\x35\xba\x62\xe4\x18\x99\xf3\x2c\x42\x96\x15\x9d\xd6\x41\x6a\xf0\x00\x94\x0f\xf7\x16\xfa\x03\xd3\x45\x54\xb1\x5b\xaf\x62\xb7\x01\xa8\xf7\x26\xa4\x37\xfe\x39\x6c\x3a\xbb\x0d\xae\x37\xd6\xcc\x60\xbc\xb5\xf6\x76\x98\xbd\x94\xe6\x60\xf3\x8c\x42\xf3\x02\x3c\x32\x7d\x02\x3a\x81\x6f\x21\x81\x33\xf0\x34\x84\xa0\x13\x1f\x62\x43\x6a\xc7\x5f\x81\xb7\x86\xd0\x04\x33\x84\x97\x3e\x69\xb7\xfe\xdc\x0d\xa5\xaf\x06\xd2\x0b\x08\xe1\x38\x81\x10\x56\x10\x1b\x57\xbe\x02\x8f\xd6\x4e\x12\xcb\x4d\xdb\xa1\xb4\x14\x1f\xb0\x2b\x54\xee\xd3\x2c\xcb\x5c\x4b\x9a\xf3\xb2\xdc\x12\x5e\xbd\x7a\xd5\x13\x44\xad\x61\x02\xee\x38\x69\x6e\x41\xb1\x5a\x85\x0a\x25\x9f\x77\xe4\x19\x2e\x78\xfd\x8e\xe9\xc2\xf3\xed\x4a\x25\x56\x78\x2d\xbc\x86\xe5\x01\x8c\x93\x6e\xb5\xe4\x75\xbf\x4a\x76\x1d\xef\x53\xc8\xe1\x97\xfb\xb4\xd6\xe0\x5e\x34\x19\x79\x4d\x79\x69\x76\xc9\xdc\xd8\xb4\x4f\x7e\x00\xc7\x01\x8c\x4f\x1f\xc0\xdc\xc4\x35\xa4\x5d\xa1\x3d\xb9\xc6\x35\xbc\x66\x1a\x3d\x9d\xf8\x91\x16\x6f\x45\xc6\x4a\x6c\x6b\x92\xe7\x9b\x5c\x6e\xed\x79\xd9\x1d\x01\xac\x29\xbb\xb7\x6c\xe3\x87\x6c\xfb\x3a\xb0\xce\x03\x30\xbe\x41\x08\xa4\xbd\x42\xa6\x96\x12\x3b\xa2\xbf\x3d\x4c\x56\x91\xb9\xfd\x84\xcc\x79\x4d\x77\x11\x74\x0f\x75\x60\x12\xa1\x62\xf2\x03\xe6\x6d\x5b\x92\x53\x23\x99\x03\x05\x56\x45\x36\x5c\xa8\xdf\xf2\x1a\x5f\x33\x55\x78\xef\x4f\x02\x38\xb9\x21\xcb\xe7\x42\x82\x47\xe6\x73\x98\xc0\xf8\x1c\x38\xa4\xb0\x7b\x66\xce\x81\xbf\x78\xd1\x1e\xbe\xc1\xc9\x7c\xcf\x6f\x22\xa3\x9b\xca\x61\xbf\xd6\x9e\x2f\xb3\x6e\xeb\xe8\xc3\x34\x18\x0d\x13\xe1\x76\x28\x4f\xfb\x5d\x52\x8c\x86\x9b\xff\x00\xb3\xdd\xb1\xd1\x7e\x22\xd0\x41\xdb\x1c\xf0\xf7\xc6\x7f\x24\xcb\x4f\x4e\x5e\xb1\xd9\xab\xc3\x09\xfb\xa9\xaa\x60\x23\xc2\xe9\xcc\x26\xf0\xc7\x1f\xa0\x3e\x3b\x24\x5b\xf7\x8d\x47\x77\x5e\x5f\x4c\xc8\xd0\xd1\x06\xb0\x54\x08\xf7\x0f\x23\x71\x18\xee\x8c\x36\xbb\x0e\x92\xfd\x9b\x43\x57\x81\x6a\x2f\xcd\xed\xd5\x60\x9f\x6e\xed\x53\x39\x00\xd7\x0c\xde\xa1\xbc\xc2\xcc\x54\xf7\x07\x48\xfb\xb4\x0d\xa0\xfb\xc4\xf6\x08\x8a\x9e\xa1\x06\x44\x83\xc3\x98\xee\xb9\x48\xb0\x6e\xbc\x67\x38\xcb\xf3\x2b\x13\x7f\x4f\x07\x60\xa2\x1b\x80\x31\xd1\xfe\xb4\x96\x06\xd0\xda\x42\xbf\x2b\xea\x02\x3b\x71\xc6\x59\xbb\x17\x51\xb3\x54\x85\x77\xaf\xcf\xa0\x13\x75\xb6\x23\xf1\xec\x80\xe0\xb3\x43\x5a\xce\x76\xb5\x9d\xed\x2b\x3d\xeb\x47\xb4\x2b\x1b\xc7\x79\x46\xd7\xe6\xff\x5d\xfd\xf4\xa3\xe7\x3e\xbc\xdf\xdd\x41\x62\xd9\xa5\xfe\x96\x2e\xf9\x0a\xb7\x97\xd9\xb9\x33\xda\xbd\xea\xbb\x7b\xcb\xb2\x1d\xcc\xd0\x6d\x00\xa9\x2c\x45\x0d\x93\x0a\x3d\x15\x5d\xf3\x0a\xfd\x00\x54\x74\x45\x11\xa0\x19\x4d\xcc\xe3\xb1\x1f\x74\x5e\xab\xe8\xc2\xfa\x4b\x23\xe3\xaa\x8a\xba\x4f\x01\xb4\xb3\x9b\xc1\x69\x21\x93\x0f\x1a\xf2\xc8\xb5\xde\x9e\x1b\x0d\xd3\xfe\xc8\xec\x81\xcc\xf9\xe9\xce\x4e\x47\x33\x5b\xa9\x76\x92\xbf\x4f\x2f\x65\x92\xdf\x3f\x77\x4c\x73\x24\xc5\xfa\xfb\xca\x5c\x52\xf4\x6e\x4f\x75\x3e\xbd\x4f\x36\x69\xac\xf3\x76\x3c\x1e\x8c\x8f\x07\xe3\x93\xc1\xf8\x74\x30\x7e\x39\x18\x7f\x6d\xc7\xf4\x32\x76\x5b\x6d\xb8\xd2\x57\x62\x29\x33\xb4\x57\xc1\x25\xb5\x69\xed\x8a\xe7\xc6\x6d\xd3\x66\x66\x74\xb6\x7a\x70\xc4\xf2\xdc\x20\xdf\x72\xa5\xb1\x46\xe9\xb9\xd4\xe4\x0d\x33\x03\xfb\x9c\xc8\x99\x66\x30\x01\x4a\x27\xbb\x9b\x18\xd1\x1a\x05\xe3\x99\xe7\x9a\xe7\xb7\xeb\x47\x8d\x44\x7a\x39\x78\xad\xff\xb6\x79\xa6\x77\x02\x61\x23\xfb\xa9\x22\xe8\xe6\x76\x83\xfb\xa9\xdd\x5c\xa2\xbb\xe3\xd8\x85\x17\x60\xd8\xba\xcf\x0f\xaf\xb1\x16\x55\xcf\x6c\x92\x65\x20\x8a\x32\xa4\x9b\xb5\x1f\x14\x9c\x91\x4f\xfb\x31\x7a\xe6\xb9\x4f\xbb\xff\x36\xf0\x23\xd3\x45\x0e\xcd\xf1\x8d\x07\x4f\xbb\xba\x32\x00\xb4\xf6\x75\x74\x6b\xdd\x2e\xc2\x2e\x5a\x4c\xff\x09\xdb\x62\x1e\xf5\xc2\xe2\xcd\x31\xdf\x11\x68\xbc\xb2\x54\x5b\xc4\x86\xea\x68\xc5\x52\xed\xa7\xd5\x21\x73\xeb\xb6\xef\xb4\xad\x41\x2d\xa8\x3f\x36\xe7\xaf\x16\x6b\xcf\xdf\x29\x31\xb6\x05\x25\x5c\xc9\x94\xfe\x78\xef\x6a\xdb\x4c\x03\x7c\xfe\x1c\xe8\xd7\xde\x39\x93\x49\xeb\x5c\x7f\x9e\xe1\xf9\x73\xa3\x78\xda\xa2\x34\xcd\xb7\x8e\xc1\x74\xd2\xae\x1b\x4b\x4c\x72\x8d\x76\x8d\x1a\x44\x01\xc2\x1d\xec\x57\x30\x4e\x92\x84\x9a\x52\x92\x6f\x69\x9a\xf2\x6f\xe3\x0c\xea\x4d\x2d\xd6\xc1\x9e\x4d\x76\x6e\xcb\xcc\x40\x5f\x00\xc3\x34\xec\x26\x94\x48\xb0\xbb\xbb\x87\x0f\xfa\xf6\x39\x9a\xc6\x94\xff\x53\x27\x8d\x0b\x5d\x95\x53\xe7\xdf\x03\x00\x35\x50\x70\xd5\x0a\x1d\x00\x00")
 
 func assetsStatsHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -170,8 +172,48 @@ func assetsStatsHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "assets/stats.html", size: 3868, mode: os.FileMode(0644), modTime: time.Unix(1550787301, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x80, 0x86, 0xc3, 0x62, 0xb1, 0xd1, 0x40, 0x2b, 0x2c, 0x13, 0x5a, 0xa9, 0xd, 0x6a, 0xa6, 0x46, 0x67, 0x7e, 0xc7, 0x19, 0xed, 0x5b, 0xf5, 0xc8, 0xb0, 0x7c, 0x3b, 0x88, 0x9e, 0xfa, 0xaa, 0x6f}}
+	info := bindataFileInfo{name: "assets/stats.html", size: 7434, mode: os.FileMode(0644), modTime: time.Unix(1792397870, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc6, 0xca, 0xe1, 0x17, 0x2d, 0xcb, 0x76, 0x2f, 0xc8, 0xa3, 0xb5, 0x66, 0xd4, 0xc2, 0x69, 0x9c, 0xfa, 0x8c, 0x42, 0x3f, 0x73, 0x8d, 0xa2, 0xe, 0xdb, 0xe3, 0x93, 0x1c, 0x5a, 0xe2, 0xc2, 0x40}}
+	return a, nil
+}
+
+var _assetsCrashersHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x56\x6b\x6f\xdb\x36\x17\xfe\x6c\xfd\x8a\xf3\xf2\x2d\x0a\x09\xb6\xa5\x38\x28\xda\xa2\x96\x54\x0c\xe9\x0d\x5b\x2f\xdb\xd2\x01\x1b\xb6\x7d\xa0\x25\x3a\x62\x4a\x93\x0a\x49\xd9\x49\x1b\xff\xf7\xe1\x50\x94\x2d\x39\x71\xd3\x02\x8d\xc9\x73\x9e\xf3\x9c\x2b\x29\xa6\xff\x7b\xf5\xe9\xec\xf3\x5f\xbf\xbe\x86\xca\xae\x44\x1e\xa4\xfe\x47\x70\xf9\x05\x34\x13\x19\x31\xf6\x46\x30\x53\x31\x66\x09\x54\x9a\x2d\x33\x92\x2c\x94\xb2\xc6\x6a\x5a\xc7\x2b\x2e\xe3\xc2\x18\xf2\xc3\x16\x53\x5b\xb1\x15\xeb\xd9\x05\xe9\x42\x95\x37\x79\x00\x90\x96\x7c\x0d\x85\xa0\xc6\x64\xa4\x50\xd2\x52\x2e\x99\x9e\x2e\x45\xc3\x4b\x82\xfa\x21\x42\xab\x8d\x97\x1e\x5a\x8a\xa9\x59\x4d\x67\xa7\x80\xab\x55\x89\xab\x15\xe5\x72\x07\x06\x48\xab\x59\x87\xae\xe9\x05\x9b\x56\x8c\x96\x4c\x93\xfc\xad\x82\x37\xcd\xd7\xaf\x69\x52\xcd\x7a\xe0\x46\x74\x60\x49\xd7\x20\xe9\x7a\x5a\x73\x21\x4c\x8f\x0f\x20\x15\x3c\x4f\x69\x97\x2c\xc9\xcf\x2d\xb5\x26\x4d\x68\x9e\x26\x82\x1f\x00\x3b\x36\x5a\x58\xbe\x66\xa4\x67\x57\x68\x6a\x2a\xa6\x4d\x8c\x3d\x20\xf9\x99\xdf\x1e\xe1\xe9\x1b\xaa\x35\xd3\xf4\x82\x75\x86\x7e\x7b\xd7\x30\x4d\x1a\x91\x07\xfb\x6d\x75\xda\x45\x63\x9a\xc5\xae\x0e\x7b\xc7\xd5\x69\xcf\xb6\x57\x64\x4b\x17\x82\x4d\x35\x33\xb5\x92\xc6\x65\xb1\x83\x01\xa4\x4e\x3b\x80\x82\xfb\x3b\x35\x56\xf3\x9a\x95\x7e\x57\x61\x98\x04\x78\x99\x91\x2e\xf3\x01\x0f\x32\x61\x48\x43\x19\x4a\xf5\xa1\x08\x85\x55\xfe\x91\xae\x58\x9a\xd8\xea\x7e\xed\x2f\x5c\x96\xc7\xb5\x1f\x98\x31\xf4\xe2\x3b\xe6\xe7\xfc\xeb\x77\xb4\x67\xaa\x91\xf6\xb8\xfa\x0d\xd7\xc6\x82\x61\x4c\xde\x87\x49\x93\xc3\x8c\x10\xe5\x32\x1f\xb9\x9d\x75\x47\x24\x4d\xda\xdf\x60\xe4\x00\x58\xc3\xbd\x55\x9a\x94\x7c\xdd\x6f\x2d\x76\xab\x57\x5a\x02\xee\x1c\x67\xa4\xe4\xa6\x16\xf4\xe6\x05\x48\x25\x0f\xfa\x76\xef\x34\xf4\x49\xa6\x92\xae\x70\x62\x07\x73\x01\x90\xd6\x38\x8b\x7d\x5c\xa9\x36\x52\x28\x5a\x92\xfc\x95\x5f\x01\x97\x75\x63\xdb\x89\xac\x87\x5e\x9f\xe4\x9f\x1a\xeb\x94\xd5\x93\x21\xad\x66\x03\x56\xe5\x60\xe8\xbf\xd6\xbd\xd4\x5b\x8e\xdf\x1a\x65\xd9\xce\xcd\x03\x4c\x57\x0e\x7c\x84\xe9\x1d\xbb\x2e\x9b\x55\xfd\x20\x49\xd5\xe2\xee\xb0\xf8\x56\xdc\xd9\xec\x96\x7e\x11\xa4\xa6\xd0\xbc\xb6\x60\x74\x91\x91\xe4\xf2\xaa\x61\xfa\xc6\xdd\x8c\x97\x06\x49\x5b\x6d\x7e\x00\x1b\xde\xbd\x43\x64\x07\xcd\x83\x65\x23\x0b\xcb\x95\x04\x1f\x64\x58\x52\x4b\x23\xf8\x16\x8c\xd6\x54\x83\x66\x06\x32\x20\x64\x1e\x8c\x96\x4a\x43\x88\x32\x0e\x19\x9c\xcc\x81\x43\x0a\x88\x8d\x05\x93\x17\xb6\x42\xc1\x38\x83\xd9\x53\x67\xeb\x8c\x2b\x76\xed\x8c\x27\x40\x4d\xc1\x79\x47\xb4\x67\xba\x84\x0c\xf8\x1c\x2e\x21\x45\x63\x98\x3d\x9d\xc3\xe5\x78\xdc\x12\x8c\xf8\x12\xc2\xcb\xa1\x0f\xaf\x19\x21\xf1\x38\x83\x90\x9c\x10\x18\xbb\x20\xfe\xbe\xfc\x37\xb6\xea\xdc\x6a\x2e\x2f\xc2\xd9\xd3\x28\x8a\x8d\xe0\x05\x0b\xa7\xa7\x11\x8c\x81\x00\x26\x30\x1a\x8d\xda\x38\xc6\x59\x67\x03\x79\x06\x27\xd7\xa7\x27\xf0\xf8\xf1\x4e\x94\xc2\xc9\xf5\xb3\x25\xbc\x84\x96\x2d\x5e\x6a\xb5\x3a\xab\xa8\x3e\x53\x25\x0b\x3d\x28\x82\x17\x40\xe2\x96\x74\x0b\x4c\x18\x36\x8c\x8c\x00\x78\x97\xdb\x7d\x2a\x59\xe6\xb2\x7c\x76\x90\x45\x17\x1c\x22\xf1\x3f\x96\xbc\x4d\xae\xfd\x87\x29\xf2\xfb\x93\x7b\xde\x26\x07\x08\x71\x6c\x40\xe0\x16\x37\x3e\x4f\x20\xb7\xff\x48\x64\xdf\x06\x23\xcd\x6c\xa3\x25\x36\x74\x1e\x6c\x83\x7d\xdb\x4d\xa5\x36\xfe\x1a\x0f\xf1\xd0\xee\x5a\xdf\x68\x81\x1d\x4b\x68\xcd\x13\x3f\xc8\x26\x41\x72\x44\xcd\x83\xd1\xa3\x90\xfc\xbf\x1b\x70\x14\x91\x28\xb6\xec\xda\xb6\x24\x07\xfa\xdd\x29\x8f\x62\x6a\xad\x0e\x09\x7e\x8e\xc8\x04\x1a\x2d\x0e\xa1\xfe\xe8\x7a\x32\x42\x0e\xf5\xfe\x40\x1e\xd5\x77\x67\xed\x18\x80\x44\x31\xa6\x1c\x3a\xde\xf8\x82\xd9\x10\x13\x1d\x03\x49\xbc\xe7\x09\x74\xb5\xe9\x8e\x02\x1c\x8f\xcf\x21\xe6\xb0\x9d\x00\x41\x77\xe4\x2e\xab\x8f\xf7\x01\xd6\x61\x56\xf7\xb1\xe2\x79\xd1\xec\x0a\x32\x90\x6c\x03\x7f\x7e\x78\xff\xce\xda\xfa\x77\x76\xd5\x30\x63\x5d\x32\x9a\x5d\xc5\xaa\x66\x32\x24\x6f\x5f\x7f\xde\xd7\x16\xc5\xfe\xf3\xcb\x3e\xdf\xd4\x0c\x7b\x4a\xb5\xa6\x37\x8b\x66\xb9\x64\x9a\x74\x96\xed\xdd\x9b\xed\xc3\x74\x83\xf0\xbd\xd2\xfa\x6d\x88\xf1\xfc\xc1\xa5\x7d\xfe\x13\xd2\x86\x7d\x87\x51\x84\x21\x6c\xbd\x0f\xc3\x64\x89\xa1\x6e\x83\xc0\xd5\xe8\xe7\xf3\x4f\x1f\xc3\xe1\x80\xf5\xeb\xd4\xc9\x5c\x20\xdd\x26\x36\x4a\xdb\x70\x87\xa1\x13\x58\x44\xf0\x0d\xfc\x74\x2f\x62\xf7\x79\x85\x29\xd0\x76\x35\x87\x2d\x46\xf0\x28\x66\xb4\xa8\xc2\x8e\xa4\xe7\x84\x4f\xa0\xd8\xdf\x58\x5a\x6d\x20\xc3\xc6\xe0\xcb\x81\x44\xf8\xf0\x0c\x49\xd1\x68\xa3\x34\x99\x00\xa9\x15\x97\xd6\x8d\x50\x21\x78\xf1\x65\x1f\x06\x86\xd0\x3f\x48\x45\x8c\x2f\x8c\xc8\x7b\x1f\x69\xb5\x89\x69\x5d\x63\xfa\x8e\xbb\xcc\x49\xd4\x17\x14\xaa\x64\x79\x57\xd6\xd6\x36\x36\xcd\xc2\x58\x1d\x9e\x4c\x60\x76\x1a\x45\xd1\x71\x1e\x6f\x84\x8f\x16\xb8\xbd\x05\x42\x1e\xc6\xfa\x27\xcc\x8f\xc2\xf1\x41\xf3\x30\xca\xd5\xfb\x61\x98\x8b\xf3\xa5\x1b\xe2\x57\xd4\xb2\xb0\x88\xdd\x93\xe7\x9c\x31\x19\xc5\x56\xbd\x57\x05\x15\xcc\xdf\x75\xee\x9a\xf5\x01\xf6\x06\xd1\x80\x7b\xde\xec\x6b\xa8\xd5\x06\x31\xae\xd5\x78\xd7\x6e\xb8\x2c\xd5\x26\x16\xaa\xa0\xd8\xe4\xb8\xa2\xa6\x6a\x9b\xdc\x6f\xd2\x7d\xa8\xae\xec\x33\xe7\x74\x1b\x20\x65\xef\xfb\x9a\xa0\xdf\x3c\x48\x93\xca\xae\x44\x1e\xfc\x37\x00\x7f\x49\x82\x28\x13\x0d\x00\x00")
+
+func assetsCrashersHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_assetsCrashersHtml,
+		"assets/crashers.html",
+	)
+}
+
+func assetsCrashersHtml() (*asset, error) {
+	bytes, err := assetsCrashersHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "assets/crashers.html", size: 3347, mode: os.FileMode(0644), modTime: time.Unix(1792397540, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x48, 0xb8, 0xcf, 0x40, 0x99, 0x41, 0xfa, 0x3d, 0xf1, 0x3d, 0x1a, 0x8b, 0x1f, 0x1f, 0xf9, 0x3e, 0xd1, 0x9c, 0x76, 0xe4, 0xc1, 0x81, 0x9c, 0x4b, 0x3e, 0x65, 0xa, 0x9c, 0xea, 0x3b, 0x4b, 0xa6}}
+	return a, nil
+}
+
+var _assetsCoverageHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x57\x51\x8f\xdb\xb8\x11\x7e\xb6\x7e\xc5\x1c\x93\x1e\xe4\xae\x25\xc5\xe9\x15\xb8\xd8\xb2\x0f\xb8\x6d\x52\xb4\x68\x73\x45\x37\x7d\x28\x72\x79\xa0\x45\xca\xe2\x2d\x45\x2a\x24\x65\x67\xb3\xb7\xff\xbd\x18\x8a\x92\xe5\xb5\x9d\x2c\x10\xf4\xc5\x36\xc9\x6f\x3e\x0e\xe7\x9b\xe1\xd0\xf9\x77\x7f\xf9\xe5\xfa\xdd\x7f\xff\xf5\x1a\x2a\x57\xcb\x75\x94\x87\x2f\x29\xd4\x2d\x18\x2e\x57\xc4\xba\x3b\xc9\x6d\xc5\xb9\x23\x50\x19\x5e\xae\x48\xb6\xd1\xda\x59\x67\x68\x93\xd6\x42\xa5\x85\xb5\xe4\xc9\x16\x89\xab\x78\xcd\xc7\x76\x9e\x7f\x1d\x3d\xb3\xba\x35\x05\x87\x7b\x28\xb5\x72\x49\x49\x6b\x21\xef\x16\x50\x6b\xa5\x6d\x43\x0b\xbe\x84\x7d\x25\x1c\x4f\xfc\x60\x01\x8d\xe1\xcb\x0e\x69\xc5\x67\xbe\x80\xf9\xcb\xe6\xd3\x12\x1e\x06\x9a\x54\x0a\x85\x64\x4c\xd8\x46\xd2\xbb\x05\x6c\xa4\x2e\x6e\x4f\x10\x4a\xc3\x3d\x14\x5a\x6a\xb3\x80\x67\xaf\x5e\xbd\x5a\x1e\x0c\x84\x42\x8a\x24\xd8\xed\x05\x73\xd5\x02\xfe\xcc\xeb\x25\x38\xfe\xc9\x25\x54\x8a\xad\x5a\x80\x11\xdb\xca\x2d\xa1\xa1\x8c\x09\xb5\x4d\xfc\x70\x01\x73\x5e\x1f\x6d\x55\xe8\x1d\xdc\xc3\x86\x16\xb7\x5b\xa3\x5b\xc5\x92\x7e\x4b\x56\x96\x2f\xd8\x8f\x47\xd8\x56\x5d\x44\x97\x2f\x19\x67\xfc\x08\xdd\x50\xe3\x04\x95\x17\xf0\x45\xf9\x23\xff\x13\xe2\xf3\x2c\x84\x39\xca\x37\x9a\xdd\xad\x23\x80\x9c\x89\x1d\x14\x92\x5a\xbb\x22\x85\x56\x8e\x0a\xc5\x4d\x52\xca\x56\x30\x82\xeb\xc7\x08\xa3\xf7\x61\xf6\xb1\xa5\x4c\x6c\x9d\xcc\x5f\x62\x14\x93\x9a\xe1\xaf\x9a\x0a\x35\x80\x01\xf2\x6a\xde\xa3\x1b\xba\xe5\x49\xc5\x29\xe3\x86\xac\xff\xaa\xe1\x4d\xfb\xf9\x73\x9e\x55\xf3\x11\xb8\x95\x3d\x58\xd1\x1d\x28\xba\x4b\x1a\x21\xa5\x1d\xf1\x01\xe4\x52\xac\x73\xda\xe7\x16\x59\xdf\x38\xea\x6c\x9e\xd1\x75\x9e\x49\x71\x19\x58\x18\x6a\x2b\x6e\x6c\x8a\x39\x4e\xd6\xd7\x61\x78\xc1\xb0\x77\x83\x16\x4e\xec\x38\x19\xf3\xe8\x1d\x37\x74\xcb\x7b\x9e\x30\x3c\xe5\xc9\xb3\x56\xae\xa3\xc3\x10\x23\x2e\xd8\x8a\x70\x63\xb4\x21\xc3\x06\x92\x1b\x07\xfe\x33\xd9\x53\xa3\x84\xda\x12\xf0\x72\xad\xc8\x90\x8d\x4a\x2b\xf4\x21\x63\x62\x37\x66\xac\x5e\xf6\x2c\xb6\xdd\x0c\x91\x7d\x23\x24\xb7\x79\x56\xbd\x1c\xb9\x32\xd2\xcc\xd1\x8d\xe4\x89\xe1\xb6\xd1\xca\xfa\xb3\x0d\x30\x80\xdc\xaf\x1e\x41\xc1\x7f\x26\xd6\x19\xd1\x70\x16\x46\x15\x9e\x9a\xf8\xe3\x94\xb8\xdd\x11\x09\xd2\xa0\x33\xc7\x73\x38\x6b\x1e\x4f\xe1\x64\xe5\x3d\xce\x33\x57\x9d\x5f\x45\x7d\x79\xcd\x95\xb3\x97\x31\x3f\x63\x9d\x7e\x61\xfd\xa0\xd2\x29\x22\xcf\x1e\xfb\x85\x28\xef\xff\xc4\x8f\x9c\x2f\x9a\x3c\xeb\xbe\xa3\x89\x07\x60\x18\x0e\x56\x27\xd2\xf4\x62\x63\x74\x2e\xe9\x39\xa0\x2f\x49\x39\x30\x24\x8a\xd6\x3e\x01\xc6\xa2\xa2\xd5\x0f\xeb\x37\xad\x2a\x9c\xd0\x0a\x15\xff\xe1\x69\x52\x16\x5a\x31\xae\x2c\x67\x61\x83\x56\x15\xdf\x2a\x60\x70\xe2\xb2\x00\xff\x10\x8a\xdb\x6f\xd3\xf8\xff\xad\x61\x17\xcf\x1b\x7f\xb5\x9e\x04\xb3\xd7\xb3\x6b\x30\x43\x2d\xf6\xeb\xde\xd6\x47\xd3\x77\x0c\x3b\x28\x78\x5e\xf9\x3e\x5d\x1f\x6d\xd2\x18\x3e\xe2\xb8\x64\x9c\x67\x8d\x39\x4d\xbd\x93\xc1\xf0\x33\xfc\x88\x72\x5b\x18\xd1\x38\xb0\xa6\x58\x91\xec\xb7\x8f\x2d\x37\x77\xbe\x15\xff\x66\x91\xb4\x5b\x5d\x3f\x82\x1d\x37\xfb\x63\x64\x0f\x5d\x47\x65\xd0\x1f\x1a\x6e\x0a\xae\x5c\x5c\xa0\x56\x9c\xcd\xc0\x69\x47\xe5\x14\xee\xa3\x89\x28\x21\xf6\x23\x58\xad\xe0\x85\x9f\x9a\x18\xee\x5a\xa3\x80\x24\x64\x19\x4d\x1e\xa2\x7e\x1c\xcf\x5f\xbc\x80\x3f\x42\x20\x81\x2c\xb0\xa4\x4e\xbf\x11\x9f\x38\x8b\xe7\x53\xb8\x02\xf2\x07\xb2\x8c\x1e\xa2\xc3\xde\xb6\xd2\x7b\xbc\x49\x62\x2c\x16\xcf\xff\x3c\xdd\x72\xf7\xf7\x9b\x5f\xde\xc6\x24\xa3\x8d\x18\xae\xee\x0c\xab\xf2\x27\x84\xad\x08\x5c\x01\x57\x85\x66\xfc\x3f\xff\xfe\xdb\xb5\xae\x1b\xad\xd0\x7f\x5c\x9b\xce\xa0\xe7\x8e\x19\x75\xd4\x53\x4e\x9e\xc7\xe4\xd9\xa1\x24\xa7\x29\x3e\x07\xfc\x72\x8a\x7b\x4f\x97\x23\x08\x99\xa6\xe8\x53\x7c\x98\xc4\x4a\x03\x7f\x8d\x90\x69\xca\xeb\xc6\xdd\x85\xc5\x94\xd3\xa2\x0a\x34\x1e\xf4\xfb\xef\xf0\xfe\xc3\xc8\x01\x31\x83\xb2\x73\x60\xb2\xa3\x06\x8c\xde\xc3\x0a\x9e\xc7\x04\x4b\x92\x4c\xf1\x35\x15\x93\xa2\x35\x56\x1b\x32\x03\xd2\x68\xa1\x1c\x37\xb8\x20\x45\x71\x1b\x0f\x34\x81\xc2\x73\xe0\x03\xa7\x23\x79\x86\x3f\x13\x0c\x45\x99\xde\x38\x6a\x1c\x56\xab\x77\x6c\xe2\x65\xc3\xe5\x54\x72\xb5\x75\x55\x4f\x80\xe7\xc1\x06\x38\x83\x70\x1a\x5b\x18\x2d\xe5\x3b\xdd\x74\x68\x5d\x96\x96\xbb\x78\x9a\x3a\xdd\x04\xa6\x07\xfc\x7c\xe8\x06\x46\xef\x53\xda\x34\x5c\xb1\xd8\x1f\x82\xad\xc9\x74\x3c\x81\x82\xac\xfb\xe8\x96\xe9\x5b\x94\x63\xfa\x05\xd3\x80\x1b\x9c\xc7\xfc\x08\x07\x7a\xad\x98\x3f\xce\xd7\xad\xfd\x05\xc3\xd9\x8d\xab\x9d\x45\x82\xac\x8f\x48\xed\xec\x57\x37\xef\x33\xff\x98\x66\x76\xb0\xef\x08\x4e\xd3\x20\xd0\x19\xbd\xf7\x88\x2e\x40\x59\x06\xff\xa4\xe6\x16\xf8\x8e\x9b\x3b\xc0\x88\xc2\x5e\xb8\xaa\x2f\x89\xcc\x3f\x13\xf1\x17\x58\xec\x8e\xa0\xcb\xee\x89\x6b\xc1\x55\xd4\x81\x6d\xa8\x02\xe1\xd2\xa8\x53\xba\xc7\xae\xe0\xfe\x61\x06\x07\x5b\x1c\x3f\x4e\xbf\xee\x6a\x3a\xce\xbc\x4d\x50\xbd\xd4\x06\x62\x24\x94\xb0\x82\xcd\x21\x55\x96\x20\x21\xc7\x99\x10\xeb\x25\xc8\xab\xab\x3e\x53\x30\x81\x36\xe9\xb5\x6e\x95\x83\xef\x86\xca\x9f\x4c\x26\xc1\x8b\xf7\xf2\x03\xac\xc0\x99\x96\x87\x34\x01\x2e\x2d\xef\x41\xad\xba\x04\x8b\xc2\x47\x17\x30\xf4\xca\x9a\x22\xe4\x73\xb8\xa5\x8f\x6a\x0c\xfd\xf0\x05\x76\xe3\x17\x83\x1b\xa8\xc7\xd1\x95\x3d\x83\x30\x26\xd3\xb4\x12\x8c\xc7\x41\xb7\x51\x88\x3a\x82\xd4\x36\x52\xb8\x98\xfc\xaa\xc8\xf8\xa6\x10\x33\xff\x0f\xa1\x3f\x65\x1f\x2d\x01\x57\x30\x5f\x9e\xa9\xbd\x1c\xb5\xc2\x2c\xa2\x8c\x5d\x63\xaf\x8e\x09\x2e\xe2\x84\x73\x26\x26\x82\x61\x41\x0f\x05\x2a\x47\x65\x39\x8a\xcc\xf7\xdf\x1f\x64\x7d\x2f\x3f\xf4\xbb\x4f\xd0\x6e\xc4\x1c\xfe\x2f\x90\xbe\x22\xbb\x50\x1f\x73\x5d\xb2\x2d\xf4\xee\x8c\xdd\x53\x76\x6d\xd5\xd8\x36\x3a\x20\x0e\x85\x74\x3e\x08\x4a\xf7\xd5\x25\x43\xf9\x1c\x19\x32\x5d\xb4\xf8\x2e\x4c\x0b\xc3\xa9\xe3\xef\xf8\x27\xf7\x56\x33\x1e\xfb\xf8\x07\xbc\x35\x45\x0f\x97\xc3\xa5\xd6\xa5\x4c\x38\x85\xf7\x19\x61\x68\x16\x93\x4e\x5c\xc0\x2b\x1e\x84\x05\xa5\x1d\xd0\x1d\x15\x12\xdf\x0a\xa0\x15\xb8\x8a\x43\xa1\xb5\x61\x42\x51\xa7\x4d\x1a\xce\x85\xa2\x86\x0a\x5c\x01\x21\x27\x39\xf3\xa5\xb2\x9a\x04\xc3\xab\xa3\xa2\xc2\x1b\x28\xc5\x1b\x28\xcc\x5d\x6b\x89\x53\xb3\x6e\x2a\x54\xda\x08\xf4\x5a\x31\x0f\xf1\x8c\x13\x02\x1d\xee\x6d\x5b\xe3\xfd\x85\x38\x3f\x73\x54\x8a\xf0\x13\x90\x20\x1f\x81\x05\x10\x3c\x6c\x3f\xf6\x2d\xf6\x57\x45\x46\x01\x1b\x15\x4b\xaf\x4b\xe7\xf9\x74\xf9\xd5\x52\x3a\x34\x42\xac\xd9\xbd\x50\x4c\xef\x53\xa9\x0b\x8a\xc1\x48\x2b\x6a\x2b\x58\x5d\x6c\xc4\xf8\x38\x98\xfa\x66\x7f\xa9\xa3\x93\x51\x64\x51\x3a\x1b\xfa\xbf\x17\xc0\x4f\x9c\xed\xa5\xdf\xd8\x4a\x0f\x4f\x8e\x32\x74\x7f\xe8\x22\x75\xb6\x4d\x5c\x6e\x6f\xde\x76\x7a\xd9\xf2\xc9\xfd\xe9\x09\xd6\x3f\x87\x5c\x1b\xcc\xbb\x89\xaf\xd9\x3f\xad\xbd\xf5\x2f\x9f\x4b\xdd\xcd\x47\x07\xaf\x9b\x73\x09\xe0\x25\x9b\x0c\x21\x65\xfc\x24\x19\xce\x59\xa5\xb6\xdd\x58\x67\xe2\x79\xd7\x61\x1f\xa2\x87\x69\x5a\x52\x21\x0f\x42\x19\xfe\xd1\x53\xa3\x73\xdd\x7f\xef\x70\x26\xc3\x3f\xa6\xe1\x7f\xb0\xbf\x3c\x0e\x59\x8a\x7e\x8e\x5e\xc4\x19\xbe\x71\xd6\x51\x9e\x55\xae\x96\xeb\xe8\x7f\x03\x00\x61\xd8\x1b\x6f\x36\x13\x00\x00")
+
+func assetsCoverageHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_assetsCoverageHtml,
+		"assets/coverage.html",
+	)
+}
+
+func assetsCoverageHtml() (*asset, error) {
+	bytes, err := assetsCoverageHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "assets/coverage.html", size: 4918, mode: os.FileMode(0644), modTime: time.Unix(1792397540, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x2b, 0x97, 0x86, 0x39, 0x47, 0x2c, 0x74, 0x5d, 0xcf, 0x8c, 0x7d, 0x9c, 0xe9, 0x97, 0x20, 0x8d, 0xe5, 0x4e, 0xdf, 0x45, 0xa8, 0xfd, 0x4e, 0x36, 0xe1, 0xfd, 0x37, 0xb5, 0xc5, 0xb7, 0x29, 0xa4}}
 	return a, nil
 }
 
@@ -272,6 +314,10 @@ var _bindata = map[string]func() (*asset, error){
 
 	"assets/bootstrap.min.js": assetsBootstrapMinJs,
 
+	"assets/coverage.html": assetsCoverageHtml,
+
+	"assets/crashers.html": assetsCrashersHtml,
+
 	"assets/jquery.min.js": assetsJqueryMinJs,
 
 	"assets/stats.html": assetsStatsHtml,
@@ -322,6 +368,8 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"bootstrap-theme.min.css": &bintree{assetsBootstrapThemeMinCss, map[string]*bintree{}},
 		"bootstrap.min.css":       &bintree{assetsBootstrapMinCss, map[string]*bintree{}},
 		"bootstrap.min.js":        &bintree{assetsBootstrapMinJs, map[string]*bintree{}},
+		"coverage.html":           &bintree{assetsCoverageHtml, map[string]*bintree{}},
+		"crashers.html":           &bintree{assetsCrashersHtml, map[string]*bintree{}},
 		"jquery.min.js":           &bintree{assetsJqueryMinJs, map[string]*bintree{}},
 		"stats.html":              &bintree{assetsStatsHtml, map[string]*bintree{}},
 	}},
@@ -0,0 +1,113 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/rpc"
+	"os"
+	"time"
+)
+
+// Coordinator started with -bin serves the archive to workers that don't have -bin,
+// and rejects workers that run a different archive.
+
+// binChunkSize is the max size of archive part sent in a single DownloadBin call.
+const binChunkSize = 4 << 20
+
+type DownloadBinArgs struct {
+	Offset int
+}
+
+type DownloadBinRes struct {
+	Hash Sig // hash of the whole archive
+	Size int // size of the whole archive
+	Data []byte
+}
+
+// DownloadBin returns part of the -bin archive starting at a.Offset.
+func (c *Coordinator) DownloadBin(a *DownloadBinArgs, r *DownloadBinRes) error {
+	// c.bin is not changed after start, so no need to lock.
+	if c.bin == nil {
+		return errors.New("coordinator does not serve test binary, it needs -bin")
+	}
+	if a.Offset < 0 || a.Offset > len(c.bin) {
+		return errors.New("bad offset")
+	}
+	end := a.Offset + binChunkSize
+	if end > len(c.bin) {
+		end = len(c.bin)
+	}
+	r.Hash = c.binHash
+	r.Size = len(c.bin)
+	r.Data = c.bin[a.Offset:end]
+	return nil
+}
+
+// dialCoordinator connects to coordinator at addr retrying for -connectiontimeout.
+// If secure is set, the connection uses TLS.
+func dialCoordinator(addr string, secure bool) (*rpc.Client, error) {
+	var c *rpc.Client
+	var err error
+	t := time.Now()
+	for {
+		if secure {
+			c, err = dialCoordinatorTLS(addr)
+		} else {
+			c, err = rpc.Dial("tcp", addr)
+		}
+		if err == nil || time.Since(t) > *flagConnectionTimeout {
+			break
+		}
+		time.Sleep(connectionPollInterval)
+	}
+	return c, err
+}
+
+// fetchBin downloads -bin archive from coordinator at addr into a temp file and returns its name.
+// Returns "" if coordinator does not serve the archive.
+func fetchBin(addr string, secure bool) string {
+	c, err := dialCoordinator(addr, secure)
+	if err != nil {
+		log.Fatalf("failed to connect to coordinator: %v", err)
+	}
+	defer c.Close()
+	var data []byte
+	var binHash Sig
+	for {
+		var res DownloadBinRes
+		if err := c.Call("Coordinator.DownloadBin", &DownloadBinArgs{Offset: len(data)}, &res); err != nil {
+			if len(data) == 0 {
+				log.Printf("can't download test binary from coordinator: %v", err)
+				return ""
+			}
+			log.Fatalf("failed to download test binary: %v", err)
+		}
+		if len(data) == 0 {
+			binHash = res.Hash
+		} else if res.Hash != binHash {
+			log.Fatalf("test binary has changed on coordinator during download")
+		}
+		data = append(data, res.Data...)
+		if len(data) >= res.Size || len(res.Data) == 0 {
+			break
+		}
+	}
+	if hash(data) != binHash {
+		log.Fatalf("downloaded test binary is corrupted: hash mismatch")
+	}
+	f, err := ioutil.TempFile("", "go-fuzz-bin")
+	if err != nil {
+		log.Fatalf("failed to create temp file: %v", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		log.Fatalf("failed to write temp file: %v", err)
+	}
+	f.Close()
+	onShutdown(func() { os.Remove(f.Name()) })
+	log.Printf("downloaded test binary from coordinator (%v bytes)", len(data))
+	return f.Name()
+}
@@ -0,0 +1,135 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// crashBucket describes all crashes with the same suppression signature.
+// Buckets are persisted as JSON files in workdir/buckets.
+type crashBucket struct {
+	Signature string
+	Kind      string // see crashKind
+	Message   string
+	Frames    []crashFrame // top frames of the crashing goroutine
+	FirstSeen time.Time
+	LastSeen  time.Time
+	Count     uint64   // number of times the crash was hit
+	Worker    int      // worker that reported the crash first
+	BinHash   string   // hash of the test binary the crash was first seen with
+	Inputs    []string // names of crashers in workdir/crashers
+
+	sig   Sig
+	dirty bool // needs to be written to disk
+}
+
+// maxBucketFrames is the number of top frames saved in crash buckets.
+const maxBucketFrames = 10
+
+func bucketsDir() string {
+	return filepath.Join(*flagWorkdir, "buckets")
+}
+
+func bucketFilename(sig Sig) string {
+	return hex.EncodeToString(sig[:]) + ".json"
+}
+
+func loadBuckets(dir string) map[Sig]*crashBucket {
+	buckets := make(map[Sig]*crashBucket)
+	os.MkdirAll(dir, 0770)
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		log.Printf("failed to read buckets dir: %v", err)
+		return buckets
+	}
+	for _, f := range files {
+		sig, ok := parseSig(strings.TrimSuffix(f.Name(), ".json"))
+		if !ok || f.IsDir() {
+			log.Printf("unexpected file in buckets dir: %v", f.Name())
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			log.Printf("failed to read bucket: %v", err)
+			continue
+		}
+		b := new(crashBucket)
+		if err := json.Unmarshal(data, b); err != nil {
+			log.Printf("failed to decode bucket %v: %v", f.Name(), err)
+			continue
+		}
+		b.sig = sig
+		buckets[sig] = b
+	}
+	return buckets
+}
+
+// noteCrash accounts crash in its bucket, creating a new bucket if necessary.
+func (c *Coordinator) noteCrash(a *NewCrasherArgs) *crashBucket {
+	sig := hash(a.Suppression)
+	now := time.Now()
+	b := c.buckets[sig]
+	if b == nil {
+		cr := parseCrash(a.Error)
+		frames := cr.frames
+		if len(frames) > maxBucketFrames {
+			frames = frames[:maxBucketFrames]
+		}
+		b = &crashBucket{
+			Signature: string(a.Suppression),
+			Kind:      cr.kind,
+			Message:   cr.msg,
+			Frames:    frames,
+			FirstSeen: now,
+			Worker:    a.ID,
+			BinHash:   hex.EncodeToString(a.BinHash[:]),
+			sig:       sig,
+		}
+		c.buckets[sig] = b
+	}
+	b.Count++
+	b.LastSeen = now
+	b.dirty = true
+	return b
+}
+
+// noteCrashHits accounts hits of already known crashes reported by workers.
+func (c *Coordinator) noteCrashHits(hits map[Sig]uint64) {
+	now := time.Now()
+	for sig, n := range hits {
+		b := c.buckets[sig]
+		if b == nil {
+			continue // Don't know anything about this crash besides the signature.
+		}
+		b.Count += n
+		b.LastSeen = now
+		b.dirty = true
+	}
+}
+
+// flushBuckets writes changed buckets to disk.
+func (c *Coordinator) flushBuckets() {
+	for sig, b := range c.buckets {
+		if !b.dirty {
+			continue
+		}
+		b.dirty = false
+		data, err := json.MarshalIndent(b, "", "\t")
+		if err != nil {
+			panic(err)
+		}
+		fname := filepath.Join(bucketsDir(), bucketFilename(sig))
+		if err := ioutil.WriteFile(fname, data, 0660); err != nil {
+			log.Printf("failed to write file: %v", err)
+		}
+	}
+}
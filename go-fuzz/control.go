@@ -0,0 +1,119 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/dvyukov/go-fuzz/go-fuzz-defs"
+)
+
+// Remote control of a running campaign, enabled with -controltoken.
+// Requests must be POSTed with "Authorization: Bearer <token>" header:
+//
+//	/api/control/pause          pause fuzzing on all workers
+//	/api/control/resume         resume fuzzing
+//	/api/control/seed           add request body as a new corpus input
+//	/api/control/drop/<name>    remove corpus input from coordinator and all workers
+func (c *Coordinator) registerControl() {
+	http.HandleFunc("/api/control/pause", c.controlHandler(c.controlPause))
+	http.HandleFunc("/api/control/resume", c.controlHandler(c.controlResume))
+	http.HandleFunc("/api/control/seed", c.controlHandler(c.controlSeed))
+	http.HandleFunc("/api/control/drop/", c.controlHandler(c.controlDrop))
+}
+
+type apiControlRes struct {
+	Paused bool
+	Name   string `json:",omitempty"` // name of the added or removed input
+}
+
+// controlHandler checks method and authorization of control requests.
+func (c *Coordinator) controlHandler(h func(w http.ResponseWriter, r *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if *flagControlToken == "" {
+			http.Error(w, "remote control is disabled, coordinator needs -controltoken", http.StatusForbidden)
+			return
+		}
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") ||
+			subtle.ConstantTimeCompare([]byte(auth[len("Bearer "):]), []byte(*flagControlToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "bad control token", http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "control requests must use POST", http.StatusMethodNotAllowed)
+			return
+		}
+		h(w, r)
+	}
+}
+
+func (c *Coordinator) controlPause(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	if !c.paused {
+		log.Printf("fuzzing is paused by remote request")
+	}
+	c.paused = true
+	c.mu.Unlock()
+	serveJSON(w, apiControlRes{Paused: true})
+}
+
+func (c *Coordinator) controlResume(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	if c.paused {
+		log.Printf("fuzzing is resumed by remote request")
+		c.resumeTime = time.Now()
+	}
+	c.paused = false
+	c.mu.Unlock()
+	serveJSON(w, apiControlRes{Paused: false})
+}
+
+func (c *Coordinator) controlSeed(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, MaxInputSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sig := hash(data)
+	name := hex.EncodeToString(sig[:])
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.addSeed(data) {
+		log.Printf("injected seed %v [%v]", name, len(data))
+	}
+	serveJSON(w, apiControlRes{Paused: c.paused, Name: name})
+}
+
+func (c *Coordinator) controlDrop(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/api/control/drop/")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	a, ok := lookupArtifact(c.corpus, name)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	c.dropInput(hash(a.data))
+	log.Printf("dropped corpus input %v [%v]", name, len(a.data))
+	serveJSON(w, apiControlRes{Paused: c.paused, Name: name})
+}
+
+// dropInput removes input from corpus and from all workers.
+// Must be called with c.mu held.
+func (c *Coordinator) dropInput(sig Sig) {
+	c.corpus.remove(sig)
+	// Remember the input so that workers that still have it do not add it back.
+	c.dropped[sig] = struct{}{}
+	for _, w := range c.workers {
+		w.drop = append(w.drop, sig)
+	}
+}
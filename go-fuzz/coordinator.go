@@ -5,9 +5,11 @@ package main
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
@@ -15,11 +17,15 @@ import (
 	"net/rpc"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/stephens2424/writerset"
+
+	. "github.com/dvyukov/go-fuzz/go-fuzz-defs"
+	. "github.com/dvyukov/go-fuzz/internal/go-fuzz-types"
 )
 
 // Coordinator manages persistent fuzzer state like input corpus and crashers.
@@ -30,22 +36,60 @@ type Coordinator struct {
 	corpus       *PersistentSet
 	suppressions *PersistentSet
 	crashers     *PersistentSet
+	slow         *PersistentSet
+	heavy        *PersistentSet
+	unstable     *PersistentSet
+	flakyBlocks  map[string]bool // locations of cover blocks reported in unstable
+	buckets      map[Sig]*crashBucket
+	rules        *suppressionRules
+	ignored      map[Sig]struct{} // suppressions of crashes matched by ignore and count rules
+	seedDirs     []*seedDir
+	syncDir      *syncDir
+	upstream     *upstreamLink // nil without -upstream
+	dedup        dedupStrategy
 
 	startTime     time.Time
 	lastInput     time.Time
+	lastProgress  time.Time        // last time corpus or coverage grew
+	progressExecs uint64           // execs at lastProgress
+	plateauSmash  time.Time        // when corpus was resmashed on plateau
+	paused        bool             // fuzzing is paused by remote control
+	resumeTime    time.Time        // when fuzzing was resumed by remote control
+	dropped       map[Sig]struct{} // corpus inputs removed by remote control
 	statExecs     uint64
 	statRestarts  uint64
-	coverFullness int
+	statResyncs   uint64 // workers resynced with corpus snapshot
+	// Statistics exported via /metrics.
+	statExecTypes     [execCount]uint64
+	statCorpusOrigins [execCount]uint64
+	statExecTime      [execTimeBuckets]uint64
+	statExecTimeSum   uint64
+	sonarSites        int
+	sonarSitesHit     int
+	coverFullness     int
+	newCrashers       int    // crashers saved during this run
+	corpusCover       []byte // merged corpus coverage of all workers
+	metadata          *MetaData
+	bin               []byte // contents of -bin archive served to workers
+	binHash           Sig
+	lastSample        *statsSample // last sample written to stats history
 
 	statsWriters *writerset.WriterSet
 }
 
 // CoordinatorWorker represents coordinator's view of a worker.
 type CoordinatorWorker struct {
-	id       int
-	procs    int
-	pending  []CoordinatorInput
-	lastSync time.Time
+	id          int
+	procs       int
+	pending     []CoordinatorInput // inputs to send to the worker, see queueInput
+	resync      []Sig              // corpus snapshot to send to the worker, see resyncWorker
+	resmash     bool               // the worker needs to smash resync inputs
+	triageQueue int                // triage queue length reported by the worker
+	drop        []Sig              // corpus inputs to remove on the worker
+	suppress    []Sig              // crash suppressions to send to the worker, see suppressCrash
+	caps        capSet             // negotiated protocol extensions
+	binHash     Sig                // hash of worker test binary
+	lastSync    time.Time
 }
 
 // coordinatorMain is entry function for coordinator.
@@ -54,17 +98,59 @@ func coordinatorMain(ln net.Listener) {
 	m.statsWriters = writerset.New()
 	m.startTime = time.Now()
 	m.lastInput = time.Now()
+	m.lastProgress = time.Now()
 	m.suppressions = newPersistentSet(filepath.Join(*flagWorkdir, "suppressions"))
-	m.crashers = newPersistentSet(filepath.Join(*flagWorkdir, "crashers"))
+	m.crashers = newCrashersSet(filepath.Join(*flagWorkdir, "crashers"))
+	m.slow = newPersistentSet(filepath.Join(*flagWorkdir, "slow"))
+	m.heavy = newPersistentSet(filepath.Join(*flagWorkdir, "heavy"))
+	m.unstable = newPersistentSet(filepath.Join(*flagWorkdir, "unstable"))
+	m.flakyBlocks = make(map[string]bool)
+	for sig := range m.unstable.m {
+		m.noteFlakyBlocks(m.unstable.readDescription(sig, "output"))
+	}
+	m.buckets = loadBuckets(bucketsDir())
+	dedup, err := parseDedupStrategy(*flagDedup)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	m.dedup = dedup
+	m.rekeySuppressions()
+	if *flagSuppress != "" {
+		m.rules = newSuppressionRules(*flagSuppress)
+	}
 	m.corpus = newPersistentSet(filepath.Join(*flagWorkdir, "corpus"))
 	if len(m.corpus.m) == 0 {
 		m.corpus.add(Artifact{[]byte{}, 0, false})
 	}
 
 	m.workers = make(map[int]*CoordinatorWorker)
+	m.ignored = make(map[Sig]struct{})
+	m.dropped = make(map[Sig]struct{})
+	m.seedDirs = newSeedDirs(*flagSeedDirs)
+	if *flagBin != "" {
+		bin, err := ioutil.ReadFile(*flagBin)
+		if err != nil {
+			log.Fatalf("failed to read bin file: %v", err)
+		}
+		m.bin = bin
+		m.binHash = hash(bin)
+	}
+	if *flagSyncDir != "" {
+		m.syncDir = newSyncDir(expandHomeDir(*flagSyncDir), *flagSyncName)
+		for _, a := range m.corpus.m {
+			m.syncDir.export(a.data)
+		}
+	}
+	if *flagUpstream != "" {
+		m.upstream = &upstreamLink{}
+	}
+	onShutdown(m.finish)
 	coordinatorListen(m)
 
 	go coordinatorLoop(m)
+	if m.upstream != nil {
+		go m.upstreamLoop()
+	}
 
 	s := rpc.NewServer()
 	s.Register(m)
@@ -74,6 +160,10 @@ func coordinatorMain(ln net.Listener) {
 func coordinatorListen(c *Coordinator) {
 	if *flagHTTP != "" {
 		http.HandleFunc("/eventsource", c.eventSource)
+		http.HandleFunc("/metrics", c.metrics)
+		c.registerAPI()
+		c.registerCoverView()
+		c.registerControl()
 		http.HandleFunc("/", c.index)
 
 		go func() {
@@ -90,6 +180,8 @@ func coordinatorLoop(c *Coordinator) {
 		if atomic.LoadUint32(&shutdown) != 0 {
 			return
 		}
+		c.pollSeedDirs()
+		c.pollSyncDir()
 		c.mu.Lock()
 		// Nuke dead workers.
 		for id, s := range c.workers {
@@ -99,12 +191,48 @@ func coordinatorLoop(c *Coordinator) {
 			log.Printf("worker %v died", s.id)
 			delete(c.workers, id)
 		}
+		c.flushBuckets()
+		if c.rules != nil {
+			if err := c.rules.reload(); err != nil {
+				log.Printf("%v", err)
+			}
+		}
+		stop := c.stopReason()
 		c.mu.Unlock()
 
 		c.broadcastStats()
+		c.recordHistory()
+		if stop != "" {
+			log.Printf("%v, stopping", stop)
+			go shutdownProcess()
+		}
 	}
 }
 
+func (c *Coordinator) noteProgress() {
+	c.lastProgress = time.Now()
+	c.progressExecs = c.statExecs
+}
+
+// checkPlateau says if corpus and coverage did not grow for -plateau.
+// With -plateausmash the first plateau queues all corpus inputs
+// for smashing on all workers and restarts the plateau timer.
+func (c *Coordinator) checkPlateau() bool {
+	if *flagPlateau == 0 || c.paused || time.Since(c.lastProgress) < *flagPlateau ||
+		time.Since(c.plateauSmash) < *flagPlateau || time.Since(c.resumeTime) < *flagPlateau {
+		return false
+	}
+	if *flagPlateauSmash && c.plateauSmash.IsZero() {
+		log.Printf("no progress for %v, smashing all %v corpus inputs", *flagPlateau, len(c.corpus.m))
+		c.plateauSmash = time.Now()
+		for _, w := range c.workers {
+			c.resyncWorker(w, true)
+		}
+		return false
+	}
+	return true
+}
+
 func (c *Coordinator) broadcastStats() {
 	stats := c.coordinatorStats()
 
@@ -141,11 +269,15 @@ func (c *Coordinator) coordinatorStats() coordinatorStats {
 	stats := coordinatorStats{
 		Corpus:           uint64(len(c.corpus.m)),
 		Crashers:         uint64(len(c.crashers.m)),
+		Slow:             uint64(len(c.slow.m)),
+		Heavy:            uint64(len(c.heavy.m)),
+		Unstable:         uint64(len(c.unstable.m)),
 		Uptime:           fmtDuration(time.Since(c.startTime)),
 		StartTime:        c.startTime,
 		LastNewInputTime: c.lastInput,
 		Execs:            c.statExecs,
 		Cover:            uint64(c.coverFullness),
+		Paused:           c.paused,
 	}
 
 	// Print stats line.
@@ -153,26 +285,53 @@ func (c *Coordinator) coordinatorStats() coordinatorStats {
 		stats.RestartsDenom = c.statExecs / c.statRestarts
 	}
 
+	stats.Workers = uint64(c.procs())
 	for _, w := range c.workers {
-		stats.Workers += uint64(w.procs)
+		stats.Pending += uint64(len(w.pending) + len(w.resync))
+		stats.TriageQueue += uint64(w.triageQueue)
 	}
 
 	return stats
 }
 
+// procs returns the number of test procs on all workers.
+func (c *Coordinator) procs() int {
+	n := 0
+	for _, w := range c.workers {
+		n += w.procs
+	}
+	return n
+}
+
 type coordinatorStats struct {
 	Workers, Corpus, Crashers, Execs, Cover, RestartsDenom uint64
+	Slow, Heavy, Unstable                                  uint64
+	Pending, TriageQueue                                   uint64 // inputs queued on coordinator and on workers
 	LastNewInputTime, StartTime                            time.Time
 	Uptime                                                 string
+	Paused                                                 bool
 }
 
 func (s coordinatorStats) String() string {
-	return fmt.Sprintf("workers: %v, corpus: %v (%v ago), crashers: %v,"+
+	str := fmt.Sprintf("workers: %v, corpus: %v (%v ago), crashers: %v,"+
 		" restarts: 1/%v, execs: %v (%.0f/sec), cover: %v, uptime: %v",
 		s.Workers, s.Corpus, fmtDuration(time.Since(s.LastNewInputTime)),
 		s.Crashers, s.RestartsDenom, s.Execs, s.ExecsPerSec(), s.Cover,
 		s.Uptime,
 	)
+	if s.Slow != 0 || s.Heavy != 0 {
+		str += fmt.Sprintf(", slow: %v, heavy: %v", s.Slow, s.Heavy)
+	}
+	if s.Unstable != 0 {
+		str += fmt.Sprintf(", unstable: %v", s.Unstable)
+	}
+	if s.Pending != 0 || s.TriageQueue != 0 {
+		str += fmt.Sprintf(", queued: %v/%v", s.Pending, s.TriageQueue)
+	}
+	if s.Paused {
+		str += ", paused"
+	}
+	return str
 }
 
 func (s coordinatorStats) ExecsPerSec() float64 {
@@ -190,12 +349,21 @@ func fmtDuration(d time.Duration) string {
 }
 
 type ConnectArgs struct {
-	Procs int
+	Procs      int
+	BinHash    Sig      // hash of worker -bin archive
+	Version    int      // protocol version, see protocolVersion
+	MinVersion int      // oldest protocol version supported by worker
+	Caps       []string // protocol extensions supported by worker
 }
 
 type ConnectRes struct {
-	ID     int
-	Corpus []CoordinatorInput
+	ID           int
+	Corpus       []CoordinatorInput // sent only if capStreamCorpus is not negotiated
+	Dedup        string             // crash deduplication strategy
+	Paused       bool               // fuzzing is paused
+	Version      int                // negotiated protocol version, see checkWorkerProtocol
+	Caps         []string           // protocol extensions supported by both coordinator and worker
+	Suppressions []Sig              // crashes that the worker should not report, see knownSuppressions
 }
 
 // CoordinatorInput is description of input that is passed between coordinator and worker.
@@ -205,6 +373,17 @@ type CoordinatorInput struct {
 	Type      execType
 	Minimized bool
 	Smashed   bool
+	Cover     *InputCover // if set, the input does not need triage
+	Resync    bool        // part of corpus snapshot, see resyncWorker
+}
+
+// InputCover is coverage of an input collected by the worker that found the input,
+// other workers with the same binary can add the input to corpus without re-running it.
+type InputCover struct {
+	BinHash  Sig    // hash of the test binary
+	Cover    []byte // compressed coverage bitmap, see compressCover
+	ExecTime uint64
+	Res      int
 }
 
 // Connect attaches new worker to coordinator.
@@ -212,25 +391,51 @@ func (c *Coordinator) Connect(a *ConnectArgs, r *ConnectRes) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	version, err := checkWorkerProtocol(a.Version, a.MinVersion)
+	if err != nil {
+		return err
+	}
+	if c.bin != nil && a.BinHash != c.binHash {
+		return fmt.Errorf("worker test binary %v does not match coordinator test binary %v",
+			hex.EncodeToString(a.BinHash[:]), hex.EncodeToString(c.binHash[:]))
+	}
 	c.idSeq++
 	w := &CoordinatorWorker{
 		id:       c.idSeq,
 		procs:    a.Procs,
+		caps:     makeCapSet(negotiateCaps(a.Caps)),
+		binHash:  a.BinHash,
 		lastSync: time.Now(),
 	}
 	c.workers[w.id] = w
 	r.ID = w.id
+	r.Dedup = c.dedup.String()
+	r.Paused = c.paused
+	r.Version = version
+	r.Caps = negotiateCaps(a.Caps)
+	if !w.caps[capControl] {
+		log.Printf("worker %v does not support remote control, it won't be paused", w.id)
+	}
+	if w.caps[capSuppressions] {
+		r.Suppressions = c.knownSuppressions()
+	}
 	// Give the worker initial corpus.
+	if w.caps[capStreamCorpus] {
+		// Send it in batches in Sync, so that neither side needs to hold all of it.
+		c.resyncWorker(w, false)
+		return nil
+	}
 	for _, a := range c.corpus.m {
-		r.Corpus = append(r.Corpus, CoordinatorInput{a.data, a.meta, execCorpus, !a.user, true})
+		r.Corpus = append(r.Corpus, CoordinatorInput{a.data, a.meta, execCorpus, !a.user, true, nil, false})
 	}
 	return nil
 }
 
 type NewInputArgs struct {
-	ID   int
-	Data []byte
-	Prio uint64
+	ID    int
+	Data  []byte
+	Prio  uint64
+	Cover *InputCover // sent only if capInputCover is negotiated
 }
 
 // NewInput saves new interesting input on coordinator.
@@ -243,24 +448,60 @@ func (c *Coordinator) NewInput(a *NewInputArgs, r *int) error {
 		return errors.New("unknown worker")
 	}
 
+	if _, ok := c.dropped[hash(a.Data)]; ok {
+		return nil
+	}
 	art := Artifact{a.Data, a.Prio, false}
 	if !c.corpus.add(art) {
 		return nil
 	}
 	c.lastInput = time.Now()
+	c.noteProgress()
+	if c.syncDir != nil {
+		c.syncDir.export(a.Data)
+	}
 	// Queue the input for sending to every worker.
+	// Other workers with the same binary can use coverage of the input
+	// instead of triaging it again.
 	for _, w1 := range c.workers {
-		w1.pending = append(w1.pending, CoordinatorInput{a.Data, a.Prio, execCorpus, true, w1 != w})
+		var cover *InputCover
+		if w1 != w && a.Cover != nil && w1.caps[capInputCover] && w1.binHash == a.Cover.BinHash {
+			cover = a.Cover
+		}
+		c.queueInput(w1, CoordinatorInput{a.Data, a.Prio, execCorpus, true, w1 != w, cover, false})
 	}
+	c.forwardInput(*a)
 
 	return nil
 }
 
+// addSeed adds new user input to corpus and queues it for triage on all workers.
+// Must be called with c.mu held.
+func (c *Coordinator) addSeed(data []byte) bool {
+	delete(c.dropped, hash(data))
+	if !c.corpus.add(Artifact{data, 0, true}) {
+		return false
+	}
+	// Workers minimize and smash it as any other user-provided input.
+	for _, w := range c.workers {
+		c.queueInput(w, CoordinatorInput{data, 0, execCorpus, false, false, nil, false})
+	}
+	// Inputs imported from sync dir are marked as exported, so they are not written back.
+	if c.syncDir != nil {
+		c.syncDir.export(data)
+	}
+	c.forwardInput(NewInputArgs{Data: data})
+	return true
+}
+
 type NewCrasherArgs struct {
+	ID          int
 	Data        []byte
 	Error       []byte
 	Suppression []byte
 	Hanging     bool
+	OutOfMemory bool
+	BinHash     Sig
 }
 
 // NewCrasher saves new crasher input on coordinator.
@@ -268,12 +509,33 @@ func (c *Coordinator) NewCrasher(a *NewCrasherArgs, r *int) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if !*flagDup && !c.suppressions.add(Artifact{a.Suppression, 0, false}) {
-		return nil // Already have this.
+	if c.rules != nil {
+		switch c.rules.match(parseCrash(a.Error)) {
+		case ruleIgnore:
+			c.ignoreCrash(hash(a.Suppression))
+			return nil
+		case ruleCount:
+			c.noteCrash(a)
+			c.ignoreCrash(hash(a.Suppression))
+			return nil
+		}
+	}
+	// Forward crashers before local deduplication, upstream may not have
+	// crashers that we have saved before joining it.
+	c.forwardCrasher(*a)
+	b := c.noteCrash(a)
+	if !*flagDup {
+		if !c.suppressions.add(Artifact{a.Suppression, 0, false}) {
+			return nil // Already have this.
+		}
+		c.suppressCrash(hash(a.Suppression))
 	}
 	if !c.crashers.add(Artifact{a.Data, 0, false}) {
 		return nil // Already have this.
 	}
+	c.newCrashers++
+	sig := hash(a.Data)
+	b.Inputs = append(b.Inputs, hex.EncodeToString(sig[:]))
 
 	// Prepare quoted version of input to simplify creation of standalone reproducers.
 	var buf bytes.Buffer
@@ -294,15 +556,141 @@ func (c *Coordinator) NewCrasher(a *NewCrasherArgs, r *int) error {
 	return nil
 }
 
+// ignoreCrash makes workers stop reporting crashes with the suppression signature,
+// so that they don't reproduce and minimize crashes that we drop anyway.
+// Hits of counted crashes are still reported in SyncArgs.Crashes.
+// Must be called with c.mu held.
+func (c *Coordinator) ignoreCrash(sig Sig) {
+	if _, ok := c.ignored[sig]; ok {
+		return
+	}
+	c.ignored[sig] = struct{}{}
+	c.suppressCrash(sig)
+}
+
+// suppressCrash queues the crash suppression signature for sending to workers.
+// Must be called with c.mu held.
+func (c *Coordinator) suppressCrash(sig Sig) {
+	for _, w := range c.workers {
+		if w.caps[capSuppressions] {
+			w.suppress = append(w.suppress, sig)
+		}
+	}
+}
+
+// knownSuppressions returns signatures of all crashes that workers should not report:
+// saved crashers (unless -dup is given) and crashes matched by ignore and count rules.
+// Must be called with c.mu held.
+func (c *Coordinator) knownSuppressions() []Sig {
+	var res []Sig
+	for sig := range c.ignored {
+		res = append(res, sig)
+	}
+	if !*flagDup {
+		for sig := range c.suppressions.m {
+			if _, ok := c.ignored[sig]; !ok {
+				res = append(res, sig)
+			}
+		}
+	}
+	return res
+}
+
+type NewSlowInputArgs struct {
+	Data  []byte
+	Ns    uint64 // execution time
+	Alloc uint64 // bytes allocated during execution
+	Heavy bool   // exceeds -alloc rather than -slow
+}
+
+// NewSlowInput saves new slow or allocation-heavy input on coordinator.
+func (c *Coordinator) NewSlowInput(a *NewSlowInputArgs, r *int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	set := c.slow
+	desc := fmt.Sprintf("execution time: %v\n", time.Duration(a.Ns))
+	if a.Heavy {
+		set = c.heavy
+		desc = fmt.Sprintf("allocated: %v MB\n", a.Alloc>>20)
+	}
+	if !set.add(Artifact{a.Data, 0, false}) {
+		return nil // Already have this.
+	}
+	set.addDescription(a.Data, []byte(desc), "output")
+	return nil
+}
+
+type NewUnstableInputArgs struct {
+	Data        []byte
+	Description []byte // unstableResultDesc line and locations of toggled cover blocks, one per line
+}
+
+const unstableResultDesc = "result differs between runs"
+
+// NewUnstableInput saves new input with nondeterministic coverage or result on coordinator.
+// Each hub learns flaky blocks on its own, so inputs that toggle only already
+// reported blocks are dropped.
+func (c *Coordinator) NewUnstableInput(a *NewUnstableInputArgs, r *int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.unstable.m[hash(a.Data)]; ok {
+		return nil // Already have this.
+	}
+	if !c.noteFlakyBlocks(a.Description) {
+		return nil
+	}
+	c.unstable.add(Artifact{a.Data, 0, false})
+	c.unstable.addDescription(a.Data, a.Description, "output")
+	return nil
+}
+
+// noteFlakyBlocks remembers flaky blocks from unstable input description.
+// Returns true if the description has new blocks or unstable result.
+// Must be called with c.mu held.
+func (c *Coordinator) noteFlakyBlocks(desc []byte) bool {
+	res := false
+	for _, line := range strings.Split(string(desc), "\n") {
+		if line == "" {
+			continue
+		}
+		if line == unstableResultDesc {
+			res = true
+			continue
+		}
+		if !c.flakyBlocks[line] {
+			c.flakyBlocks[line] = true
+			res = true
+		}
+	}
+	return res
+}
+
 type SyncArgs struct {
 	ID            int
+	Procs         int // test procs in the subtree, sent by coordinators with -upstream
 	Execs         uint64
 	Restarts      uint64
 	CoverFullness int
+	Crashes       map[Sig]uint64 // hits of already known crashes by suppression signature
+	ExecTypes     []uint64       // execs by execType
+	CorpusOrigins []uint64       // new corpus inputs by execType
+	ExecTime      []uint64       // histogram of exec times, see execTimeBounds
+	ExecTimeSum   uint64         // total exec time in ns
+	SonarSites    int
+	SonarSitesHit int
+	CorpusCover   []byte // sent only when changed
+	TriageQueue   int    // number of inputs waiting for triage on the worker
+	Resync        bool   // worker dropped inputs that did not fit into its triage queue
 }
 
 type SyncRes struct {
-	Inputs []CoordinatorInput // new interesting inputs
+	Inputs       []CoordinatorInput // new interesting inputs, see takeInputs
+	ResyncLeft   int                // corpus snapshot inputs that are not sent yet
+	Drop         []Sig              // corpus inputs to remove
+	Paused       bool               // fuzzing is paused
+	Suppressions []Sig              // new crashes that the worker should not report, see suppressCrash
 }
 
 var errUnkownWorker = errors.New("unknown worker")
@@ -319,11 +707,49 @@ func (c *Coordinator) Sync(a *SyncArgs, r *SyncRes) error {
 	}
 	c.statExecs += a.Execs
 	c.statRestarts += a.Restarts
+	c.noteCrashHits(a.Crashes)
+	c.forwardCrashHits(a.Crashes)
+	addCounters(c.statExecTypes[:], a.ExecTypes)
+	addCounters(c.statCorpusOrigins[:], a.CorpusOrigins)
+	addCounters(c.statExecTime[:], a.ExecTime)
+	c.statExecTimeSum += a.ExecTimeSum
+	if len(a.CorpusCover) == CoverSize {
+		if c.corpusCover == nil {
+			c.corpusCover = make([]byte, CoverSize)
+		}
+		updateMaxCover(c.corpusCover, a.CorpusCover, nil)
+		if c.upstream != nil {
+			c.upstream.coverStale = true
+		}
+	}
+	if a.Procs != 0 {
+		w.procs = a.Procs
+	}
+	if a.SonarSites != 0 {
+		c.sonarSites = a.SonarSites
+	}
+	if c.sonarSitesHit < a.SonarSitesHit {
+		c.sonarSitesHit = a.SonarSitesHit
+	}
 	if c.coverFullness < a.CoverFullness {
 		c.coverFullness = a.CoverFullness
+		c.noteProgress()
 	}
 	w.lastSync = time.Now()
-	r.Inputs = w.pending
-	w.pending = nil
+	w.triageQueue = a.TriageQueue
+	if a.Resync {
+		log.Printf("worker %v dropped inputs, resyncing it with corpus snapshot", w.id)
+		c.resyncWorker(w, w.resync != nil && w.resmash)
+		c.statResyncs++
+	}
+	r.Inputs = c.takeInputs(w)
+	r.ResyncLeft = len(w.resync)
+	if w.caps[capControl] {
+		r.Drop = w.drop
+		r.Paused = c.paused
+	}
+	w.drop = nil
+	r.Suppressions = w.suppress
+	w.suppress = nil
 	return nil
 }
@@ -4,6 +4,8 @@
 package main
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -16,11 +18,50 @@ func makeCopy(data []byte) []byte {
 	return append([]byte{}, data...)
 }
 
-func compareCover(base, cur []byte) bool {
+// compressCover encodes sparse coverage bitmap as a sequence of
+// (uvarint distance from the previous non-zero counter, counter) pairs.
+func compressCover(cover []byte) []byte {
+	var buf []byte
+	var tmp [binary.MaxVarintLen64]byte
+	prev := 0
+	for i, v := range cover {
+		if v == 0 {
+			continue
+		}
+		n := binary.PutUvarint(tmp[:], uint64(i-prev))
+		buf = append(buf, tmp[:n]...)
+		buf = append(buf, v)
+		prev = i
+	}
+	return buf
+}
+
+func decompressCover(data []byte) ([]byte, error) {
+	cover := make([]byte, CoverSize)
+	idx := 0
+	for len(data) != 0 {
+		delta, n := binary.Uvarint(data)
+		if n <= 0 || n >= len(data) || delta >= CoverSize || idx+int(delta) >= CoverSize {
+			return nil, errors.New("corrupted coverage bitmap")
+		}
+		idx += int(delta)
+		cover[idx] = data[n]
+		data = data[n+1:]
+	}
+	return cover, nil
+}
+
+// compareCover says if cur has coverage that is not in base.
+// Blocks that are nonzero in flaky (can be nil) are ignored.
+func compareCover(base, cur, flaky []byte) bool {
 	if len(base) != CoverSize || len(cur) != CoverSize {
 		log.Fatalf("bad cover table size (%v, %v)", len(base), len(cur))
 	}
 	res := compareCoverBody(base, cur)
+	if res && flaky != nil {
+		// New coverage is rare, so check flaky blocks only on the slow path.
+		res = compareCoverMasked(base, cur, flaky)
+	}
 	if false {
 		// This check can legitimately fail if the test process has
 		// some background goroutines that continue to write to the
@@ -41,7 +82,18 @@ func compareCoverDump(base, cur []byte) bool {
 	return false
 }
 
-func updateMaxCover(base, cur []byte) int {
+func compareCoverMasked(base, cur, flaky []byte) bool {
+	for i, v := range base {
+		if cur[i] > v && flaky[i] == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// updateMaxCover merges cur into base and returns the number of covered blocks in base.
+// Blocks that are nonzero in flaky (can be nil) are not merged.
+func updateMaxCover(base, cur, flaky []byte) int {
 	if len(base) != CoverSize || len(cur) != CoverSize {
 		log.Fatalf("bad cover table size (%v, %v)", len(base), len(cur))
 	}
@@ -49,11 +101,12 @@ func updateMaxCover(base, cur []byte) int {
 	for i, x := range cur {
 		x = roundUpCover(x)
 		v := base[i]
-		if v != 0 || x > 0 {
-			cnt++
-		}
-		if v < x {
+		if v < x && (flaky == nil || flaky[i] == 0) {
 			base[i] = x
+			v = x
+		}
+		if v != 0 {
+			cnt++
 		}
 	}
 	return cnt
@@ -79,11 +132,11 @@ func roundUpCover(x byte) byte {
 	return 255
 }
 
-func findNewCover(base, cover []byte) (res []byte, notEmpty bool) {
+func findNewCover(base, cover, flaky []byte) (res []byte, notEmpty bool) {
 	res = make([]byte, CoverSize)
 	for i, b := range base {
 		c := cover[i]
-		if c > b {
+		if c > b && (flaky == nil || flaky[i] == 0) {
 			res[i] = c
 			notEmpty = true
 		}
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"bytes"
 	"testing"
 
 	. "github.com/dvyukov/go-fuzz/go-fuzz-defs"
@@ -36,3 +37,46 @@ func BenchmarkCompareCoverBody(b *testing.B) {
 		}
 	})
 }
+
+func TestCompressCover(t *testing.T) {
+	for _, idx := range [][]int{nil, {0}, {CoverSize - 1}, {0, 1, 2, 1000, CoverSize - 1}, {300, 40000}} {
+		cover := make([]byte, CoverSize)
+		for i, v := range idx {
+			cover[v] = byte(i + 1)
+		}
+		data := compressCover(cover)
+		got, err := decompressCover(data)
+		if err != nil {
+			t.Fatalf("%v: decompress failed: %v", idx, err)
+		}
+		if !bytes.Equal(got, cover) {
+			t.Fatalf("%v: cover does not roundtrip", idx)
+		}
+		if len(data) != 0 {
+			if _, err := decompressCover(data[:len(data)-1]); err == nil {
+				t.Fatalf("%v: truncated cover is decompressed", idx)
+			}
+		}
+	}
+}
+
+func TestFlakyCover(t *testing.T) {
+	base := make([]byte, CoverSize)
+	cur := make([]byte, CoverSize)
+	flaky := make([]byte, CoverSize)
+	cur[10] = 1
+	flaky[10] = 1
+	if compareCover(base, cur, flaky) {
+		t.Fatalf("flaky block is new coverage")
+	}
+	if !compareCover(base, cur, nil) {
+		t.Fatalf("block is not new coverage without flaky mask")
+	}
+	cur[20] = 1
+	if !compareCover(base, cur, flaky) {
+		t.Fatalf("non-flaky block is not new coverage")
+	}
+	if n := updateMaxCover(base, cur, flaky); n != 1 || base[10] != 0 || base[20] == 0 {
+		t.Fatalf("bad cover update: covered %v, flaky %v, new %v", n, base[10], base[20])
+	}
+}
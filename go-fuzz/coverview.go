@@ -0,0 +1,186 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+
+	. "github.com/dvyukov/go-fuzz/internal/go-fuzz-types"
+)
+
+// Coverage view for the web UI:
+//
+//	/api/coverage              per-file coverage summary
+//	/api/coverage/file?name=F  source, blocks and per-function coverage of file F
+
+type apiCoverFile struct {
+	File          string
+	Blocks        int
+	CoveredBlocks int
+	Stmts         int
+	CoveredStmts  int
+}
+
+type apiCoverBlock struct {
+	StartLine int
+	StartCol  int
+	EndLine   int
+	EndCol    int
+	NumStmt   int
+	Count     int // value of the coverage counter (0 means not covered)
+}
+
+type apiCoverFunc struct {
+	Name         string
+	StartLine    int
+	EndLine      int
+	Stmts        int
+	CoveredStmts int
+}
+
+type apiCoverSource struct {
+	File   string
+	Source string `json:",omitempty"` // empty if the source file is not available on coordinator
+	Blocks []apiCoverBlock
+	Funcs  []apiCoverFunc
+}
+
+func (c *Coordinator) registerCoverView() {
+	http.HandleFunc("/api/coverage", c.apiCoverage)
+	http.HandleFunc("/api/coverage/file", c.apiCoverageFile)
+}
+
+// coverMetadata returns metadata of the -bin archive, loading it on first use.
+// Must be called with c.mu held.
+func (c *Coordinator) coverMetadata() *MetaData {
+	if c.metadata == nil && *flagBin != "" {
+		metadata, err := readMetadata(*flagBin)
+		if err != nil {
+			log.Printf("failed to read metadata: %v", err)
+			return nil
+		}
+		c.metadata = &metadata
+	}
+	return c.metadata
+}
+
+// coverBlocks returns cover blocks of all files with the corresponding counter values.
+func (c *Coordinator) coverBlocks() (map[string][]apiCoverBlock, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	metadata := c.coverMetadata()
+	if metadata == nil {
+		return nil, false
+	}
+	files := make(map[string][]apiCoverBlock)
+	for _, b := range metadata.Blocks {
+		cnt := 0
+		if c.corpusCover != nil {
+			cnt = int(c.corpusCover[b.ID])
+		}
+		files[b.File] = append(files[b.File], apiCoverBlock{b.StartLine, b.StartCol, b.EndLine, b.EndCol, b.NumStmt, cnt})
+	}
+	return files, true
+}
+
+func (c *Coordinator) apiCoverage(w http.ResponseWriter, r *http.Request) {
+	files, ok := c.coverBlocks()
+	if !ok {
+		http.Error(w, "coverage is not available, coordinator needs -bin", http.StatusNotFound)
+		return
+	}
+	res := []apiCoverFile{}
+	for file, blocks := range files {
+		f := apiCoverFile{File: file, Blocks: len(blocks)}
+		for _, b := range blocks {
+			f.Stmts += b.NumStmt
+			if b.Count != 0 {
+				f.CoveredBlocks++
+				f.CoveredStmts += b.NumStmt
+			}
+		}
+		res = append(res, f)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].File < res[j].File })
+	serveJSON(w, res)
+}
+
+func (c *Coordinator) apiCoverageFile(w http.ResponseWriter, r *http.Request) {
+	files, ok := c.coverBlocks()
+	if !ok {
+		http.Error(w, "coverage is not available, coordinator needs -bin", http.StatusNotFound)
+		return
+	}
+	name := r.FormValue("name")
+	blocks, ok := files[name] // only files from metadata can be read
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		if blocks[i].StartLine != blocks[j].StartLine {
+			return blocks[i].StartLine < blocks[j].StartLine
+		}
+		return blocks[i].StartCol < blocks[j].StartCol
+	})
+	res := &apiCoverSource{File: name, Blocks: blocks}
+	if src, err := ioutil.ReadFile(name); err == nil {
+		res.Source = string(src)
+		res.Funcs = funcCoverage(name, src, blocks)
+	}
+	serveJSON(w, res)
+}
+
+// funcCoverage calculates per-function statement coverage of the source file.
+func funcCoverage(name string, src []byte, blocks []apiCoverBlock) []apiCoverFunc {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, src, 0)
+	if err != nil {
+		return nil
+	}
+	var funcs []apiCoverFunc
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		f := apiCoverFunc{
+			Name:      fn.Name.Name,
+			StartLine: fset.Position(fn.Pos()).Line,
+			EndLine:   fset.Position(fn.End()).Line,
+		}
+		if fn.Recv != nil && len(fn.Recv.List) != 0 {
+			f.Name = "(" + recvName(fn.Recv.List[0].Type) + ")." + f.Name
+		}
+		for _, b := range blocks {
+			if b.StartLine < f.StartLine || b.EndLine > f.EndLine {
+				continue
+			}
+			f.Stmts += b.NumStmt
+			if b.Count != 0 {
+				f.CoveredStmts += b.NumStmt
+			}
+		}
+		funcs = append(funcs, f)
+	}
+	return funcs
+}
+
+func recvName(typ ast.Expr) string {
+	switch t := typ.(type) {
+	case *ast.StarExpr:
+		return "*" + recvName(t.X)
+	case *ast.Ident:
+		return t.Name
+	case *ast.IndexExpr:
+		return recvName(t.X)
+	}
+	return "?"
+}
@@ -0,0 +1,138 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// crashReport is a parsed crash output of a testee.
+type crashReport struct {
+	msg    string       // crash message, e.g. "panic: runtime error: index out of range"
+	kind   string       // see crashKind
+	frames []crashFrame // stack of the crashing goroutine
+}
+
+type crashFrame struct {
+	Func string
+	File string
+	Line int
+}
+
+// parseCrash extracts crash message and stack of the crashing goroutine from testee output.
+func parseCrash(out []byte) *crashReport {
+	cr := new(crashReport)
+	collect := false
+	pending := false // last frame waits for its file:line
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		line := s.Text()
+		if cr.msg == "" {
+			switch {
+			case strings.HasPrefix(line, "program hanged"):
+				cr.kind = "hang"
+			case strings.HasPrefix(line, "program exceeded memory limit"):
+				cr.kind = "out of memory"
+				cr.msg = line
+			case isCrashMessage(line):
+				cr.msg = line
+			}
+			continue
+		}
+		if !collect {
+			// The first goroutine after the message is the crashing one.
+			// This also skips "runtime stack:" which does not have a goroutine header.
+			collect = strings.HasPrefix(line, "goroutine ") && strings.Contains(line, " [")
+			continue
+		}
+		if line == "" {
+			break
+		}
+		if line[0] == '\t' {
+			if pending {
+				f := &cr.frames[len(cr.frames)-1]
+				f.File, f.Line = parseFrameFile(line[1:])
+				pending = false
+			}
+			continue
+		}
+		pending = false
+		// This also skips "created by" lines, which are not frames.
+		idx := strings.LastIndex(line, "(")
+		if idx == -1 || strings.HasPrefix(line, "created by ") {
+			continue
+		}
+		cr.frames = append(cr.frames, crashFrame{Func: line[:idx]})
+		pending = true
+	}
+	if cr.kind == "" {
+		cr.kind = crashKind(cr.msg)
+	}
+	return cr
+}
+
+// isCrashMessage says if line starts crash message in testee output.
+func isCrashMessage(line string) bool {
+	return strings.HasPrefix(line, "panic: ") ||
+		strings.HasPrefix(line, "fatal error: ") ||
+		strings.HasPrefix(line, "SIG") && strings.Contains(line, ": ") ||
+		strings.HasPrefix(line, "hang: ") ||
+		strings.HasPrefix(line, "goroutine leak: ")
+}
+
+// parseFrameFile parses "/path/to/file.go:123 +0x1f" stack line.
+func parseFrameFile(s string) (file string, line int) {
+	if idx := strings.LastIndex(s, " +0x"); idx != -1 {
+		s = s[:idx]
+	}
+	idx := strings.LastIndex(s, ":")
+	if idx == -1 {
+		return s, 0
+	}
+	line, err := strconv.Atoi(s[idx+1:])
+	if err != nil {
+		return s, 0
+	}
+	return s[:idx], line
+}
+
+// crashKind classifies crash by its message.
+func crashKind(msg string) string {
+	switch {
+	case msg == "":
+		return "unknown"
+	case strings.HasPrefix(msg, "hang: "):
+		return "hang"
+	case strings.HasPrefix(msg, "goroutine leak: "):
+		return "goroutine leak"
+	case strings.Contains(msg, "out of memory"):
+		return "out of memory"
+	case strings.Contains(msg, "nil pointer dereference"):
+		return "nil deref"
+	case strings.Contains(msg, "index out of range"):
+		return "index out of range"
+	case strings.Contains(msg, "slice bounds out of range"):
+		return "slice bounds out of range"
+	case strings.HasPrefix(msg, "panic: runtime error: "):
+		return "runtime error"
+	case strings.HasPrefix(msg, "panic: ("):
+		// Panic with a value of non-error type: "panic: (main.T) {...}".
+		if idx := strings.Index(msg, ") "); idx != -1 {
+			return "panic " + msg[len("panic: "):idx+1]
+		}
+		return "panic"
+	case strings.HasPrefix(msg, "panic: "):
+		return "panic"
+	case strings.HasPrefix(msg, "fatal error: stack overflow"):
+		return "stack overflow"
+	case strings.HasPrefix(msg, "fatal error: "):
+		return "fatal error"
+	case strings.HasPrefix(msg, "SIG"):
+		return "signal"
+	}
+	return "unknown"
+}
@@ -0,0 +1,251 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCrash(t *testing.T) {
+	tests := []struct {
+		out    string
+		msg    string
+		kind   string
+		frames []crashFrame
+	}{
+		{
+			out: `panic: runtime error: index out of range [5] with length 3
+
+goroutine 1 [running]:
+github.com/foo/bar.parse(...)
+	/home/foo/bar/parse.go:12
+github.com/foo/bar.Fuzz({0x7f0e8c2fe000, 0x6, 0x6})
+	/home/foo/bar/fuzz.go:7 +0x1d
+go-fuzz-dep.Main({0xc000094f68, 0x1, 0x1})
+	go-fuzz-dep/main.go:36 +0x15b
+main.main()
+	github.com/foo/bar/go.fuzz.main/main.go:15 +0x3b
+exit status 2`,
+			msg:  "panic: runtime error: index out of range [5] with length 3",
+			kind: "index out of range",
+			frames: []crashFrame{
+				{"github.com/foo/bar.parse", "/home/foo/bar/parse.go", 12},
+				{"github.com/foo/bar.Fuzz", "/home/foo/bar/fuzz.go", 7},
+				{"go-fuzz-dep.Main", "go-fuzz-dep/main.go", 36},
+				{"main.main", "github.com/foo/bar/go.fuzz.main/main.go", 15},
+			},
+		},
+		{
+			out: `program hanged (timeout 10 seconds)
+
+hang: fuzz function did not return in 10s
+
+goroutine 1 [chan receive]:
+github.com/foo/bar.wait(...)
+	/home/foo/bar/wait.go:3 +0x1
+created by github.com/foo/bar.start in goroutine 1
+	/home/foo/bar/wait.go:9 +0x2
+
+goroutine 5 [select]:
+github.com/foo/bar.other()
+	/home/foo/bar/other.go:1 +0x1
+`,
+			msg:  "hang: fuzz function did not return in 10s",
+			kind: "hang",
+			frames: []crashFrame{
+				{"github.com/foo/bar.wait", "/home/foo/bar/wait.go", 3},
+			},
+		},
+		{
+			out: `runtime: goroutine stack exceeds 1000000000-byte limit
+fatal error: stack overflow
+
+runtime stack:
+runtime.throw({0x4c5e8f, 0xe})
+	/usr/local/go/src/runtime/panic.go:1198 +0x71
+
+goroutine 1 [running]:
+github.com/foo/bar.rec(0x1)
+	/home/foo/bar/rec.go:5 +0x1
+`,
+			msg:  "fatal error: stack overflow",
+			kind: "stack overflow",
+			frames: []crashFrame{
+				{"github.com/foo/bar.rec", "/home/foo/bar/rec.go", 5},
+			},
+		},
+		{
+			out:  "panic: (main.T) {0x1234}\n\ngoroutine 1 [running]:\n",
+			msg:  "panic: (main.T) {0x1234}",
+			kind: "panic (main.T)",
+		},
+		{
+			out:  "some garbage",
+			kind: "unknown",
+		},
+	}
+	for i, test := range tests {
+		cr := parseCrash([]byte(test.out))
+		if cr.msg != test.msg {
+			t.Errorf("#%v: msg = %q, want %q", i, cr.msg, test.msg)
+		}
+		if cr.kind != test.kind {
+			t.Errorf("#%v: kind = %q, want %q", i, cr.kind, test.kind)
+		}
+		if len(cr.frames) != 0 || len(test.frames) != 0 {
+			if !reflect.DeepEqual(cr.frames, test.frames) {
+				t.Errorf("#%v: frames = %+v, want %+v", i, cr.frames, test.frames)
+			}
+		}
+	}
+}
+
+func TestSuppressionRules(t *testing.T) {
+	rules, err := parseSuppressionRules([]byte(`[
+		{"Action": "ignore", "Message": "unreachable", "Frame": "^foo\\.bar$"},
+		{"Action": "count", "Frame": "^encoding/json\\."}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sr := &suppressionRules{rules: rules}
+	tests := []struct {
+		cr     *crashReport
+		action string
+	}{
+		{&crashReport{msg: "panic: unreachable", frames: []crashFrame{{Func: "foo.baz"}, {Func: "foo.bar"}}}, ruleIgnore},
+		{&crashReport{msg: "panic: unreachable", frames: []crashFrame{{Func: "foo.baz"}}}, ""},
+		{&crashReport{msg: "panic: oops", frames: []crashFrame{{Func: "encoding/json.Unmarshal"}}}, ruleCount},
+		{&crashReport{msg: "panic: oops"}, ""},
+	}
+	for i, test := range tests {
+		if action := sr.match(test.cr); action != test.action {
+			t.Errorf("#%v: action = %q, want %q", i, action, test.action)
+		}
+	}
+	for _, bad := range []string{`[{"Action": "drop", "Message": "x"}]`, `[{"Action": "count"}]`, `[{"Action": "count", "Frame": "("}]`} {
+		if _, err := parseSuppressionRules([]byte(bad)); err == nil {
+			t.Errorf("parsed bad rules %v", bad)
+		}
+	}
+}
+
+func TestDedupStrategy(t *testing.T) {
+	const out = `panic: runtime error: index out of range [5] with length 3
+
+goroutine 1 [running]:
+foo.parse(...)
+	/foo/parse.go:10 +0x1
+foo.parseList(...)
+	/foo/parse.go:20 +0x1
+foo.parse(...)
+	/foo/parse.go:10 +0x1
+foo.parseList(...)
+	/foo/parse.go:20 +0x1
+foo.parse(...)
+	/foo/parse.go:10 +0x1
+foo.Fuzz(...)
+	/foo/fuzz.go:5 +0x1
+go-fuzz-dep.Main(...)
+	go-fuzz-dep/main.go:36 +0x1
+main.main()
+	go-fuzz.main/main.go:15 +0x1
+`
+	tests := []struct {
+		strategy string
+		supp     string
+	}{
+		{"full", "panic: runtime error: index out of range [5] with length 3\nfoo.parse\nfoo.parseList\nfoo.parse\nfoo.parseList\nfoo.parse\nfoo.Fuzz\ngo-fuzz-dep.Main\nmain.main\n"},
+		{"collapse,noruntime", "panic: runtime error: index out of range [5] with length 3\nfoo.parse\nfoo.parseList\nfoo.parse\nfoo.Fuzz\nmain.main\n"},
+		{"top=2,nomsg", "index out of range\nfoo.parse\nfoo.parseList\n"},
+	}
+	for _, test := range tests {
+		ds, err := parseDedupStrategy(test.strategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ds.String() != test.strategy {
+			t.Errorf("%v: canonical form %v", test.strategy, ds)
+		}
+		if supp := string(extractSuppression([]byte(out), ds)); supp != test.supp {
+			t.Errorf("%v: got suppression:\n%v\nwant:\n%v", test.strategy, supp, test.supp)
+		}
+	}
+	if _, err := parseDedupStrategy("top=0"); err == nil {
+		t.Errorf("parsed bad strategy")
+	}
+}
+
+func TestExtractSuppression(t *testing.T) {
+	ds, err := parseDedupStrategy("full")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		out  string
+		supp string
+	}{
+		{
+			`goroutine leak: 2 new goroutines did not exit in 1s
+
+goroutine 7 [chan receive]:
+foo.worker(...)
+	/foo/worker.go:10 +0x1
+created by foo.Fuzz
+	/foo/fuzz.go:5 +0x1
+
+goroutine 8 [chan receive]:
+foo.worker(...)
+	/foo/worker.go:10 +0x1
+created by foo.Fuzz
+	/foo/fuzz.go:5 +0x1
+`,
+			"goroutine leak\nfoo.worker\n",
+		},
+		{
+			`program exceeded memory limit (100 MB)
+
+out of memory: fuzz function allocated 399 MB, limit is 100 MB
+
+goroutine 1 [running]:
+foo.grow(...)
+	/foo/grow.go:10 +0x1
+foo.Fuzz(...)
+	/foo/fuzz.go:5 +0x1
+go-fuzz-dep.Main(...)
+	go-fuzz-dep/main.go:36 +0x1
+main.main()
+	go-fuzz.main/main.go:15 +0x1
+
+goroutine 5 [sleep]:
+time.Sleep(...)
+	runtime/time.go:368 +0x1
+`,
+			"out of memory\nfoo.grow\nfoo.Fuzz\ngo-fuzz-dep.Main\nmain.main\n",
+		},
+		{
+			`program hanged (timeout 10 seconds)
+
+hang: fuzz function did not return in 10s
+
+goroutine 1 [select (no cases)]:
+foo.wait(...)
+	/foo/wait.go:10 +0x1
+foo.Fuzz(...)
+	/foo/fuzz.go:5 +0x1
+go-fuzz-dep.Main(...)
+	go-fuzz-dep/main.go:36 +0x1
+main.main()
+	go-fuzz.main/main.go:15 +0x1
+`,
+			"hang\nfoo.wait\nfoo.Fuzz\ngo-fuzz-dep.Main\nmain.main\n",
+		},
+	}
+	for i, test := range tests {
+		if supp := string(extractSuppression([]byte(test.out), ds)); supp != test.supp {
+			t.Errorf("#%v: got suppression:\n%v\nwant:\n%v", i, supp, test.supp)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// dedupStrategy says how crash suppression signatures are derived from crash stacks.
+// The zero value corresponds to the full stack with crash message.
+type dedupStrategy struct {
+	top       int  // use only top N frames (0 means all)
+	collapse  bool // collapse repeated runs of recursive frames
+	noRuntime bool // ignore runtime and go-fuzz-dep frames
+	noMsg     bool // use crash kind instead of crash message
+}
+
+// maxRecursionPeriod is the longest cycle of mutually recursive frames that is collapsed.
+const maxRecursionPeriod = 8
+
+// parseDedupStrategy parses -dedup flag value of the form "top=5,collapse,noruntime,nomsg".
+func parseDedupStrategy(s string) (dedupStrategy, error) {
+	var ds dedupStrategy
+	if s == "" || s == "full" {
+		return ds, nil
+	}
+	for _, opt := range strings.Split(s, ",") {
+		switch {
+		case strings.HasPrefix(opt, "top="):
+			n, err := strconv.Atoi(opt[len("top="):])
+			if err != nil || n <= 0 {
+				return ds, fmt.Errorf("bad dedup option %q", opt)
+			}
+			ds.top = n
+		case opt == "collapse":
+			ds.collapse = true
+		case opt == "noruntime":
+			ds.noRuntime = true
+		case opt == "nomsg":
+			ds.noMsg = true
+		default:
+			return ds, fmt.Errorf("unknown dedup option %q", opt)
+		}
+	}
+	return ds, nil
+}
+
+// String returns canonical form of the strategy, suitable for parseDedupStrategy.
+func (ds dedupStrategy) String() string {
+	var opts []string
+	if ds.top != 0 {
+		opts = append(opts, fmt.Sprintf("top=%v", ds.top))
+	}
+	if ds.collapse {
+		opts = append(opts, "collapse")
+	}
+	if ds.noRuntime {
+		opts = append(opts, "noruntime")
+	}
+	if ds.noMsg {
+		opts = append(opts, "nomsg")
+	}
+	if len(opts) == 0 {
+		return "full"
+	}
+	return strings.Join(opts, ",")
+}
+
+// apply transforms full suppression signature (crash message followed by function names,
+// one per line) according to the strategy. It can also be applied to a signature
+// produced by a different strategy, though information lost by that strategy is not restored.
+func (ds dedupStrategy) apply(supp []byte) []byte {
+	if ds == (dedupStrategy{}) {
+		return supp
+	}
+	lines := strings.Split(strings.TrimSuffix(string(supp), "\n"), "\n")
+	msg, frames := lines[0], lines[1:]
+	if ds.noMsg {
+		if kind := crashKind(msg); kind != "unknown" {
+			msg = kind
+		}
+	}
+	if ds.noRuntime {
+		frames1 := frames[:0:0]
+		for _, f := range frames {
+			if f == "panic" || strings.HasPrefix(f, "runtime.") || strings.HasPrefix(f, "go-fuzz-dep.") {
+				continue
+			}
+			frames1 = append(frames1, f)
+		}
+		frames = frames1
+	}
+	if ds.collapse {
+		frames = collapseRecursion(frames)
+	}
+	if ds.top != 0 && len(frames) > ds.top {
+		frames = frames[:ds.top]
+	}
+	var buf bytes.Buffer
+	buf.WriteString(msg)
+	buf.WriteByte('\n')
+	for _, f := range frames {
+		buf.WriteString(f)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// collapseRecursion replaces repeated runs of frame cycles (a, a, a or a, b, a, b)
+// with a single occurrence of the cycle.
+func collapseRecursion(frames []string) []string {
+	for p := 1; p <= maxRecursionPeriod; p++ {
+		res := frames[:0:0]
+		for i := 0; i < len(frames); {
+			if len(res) >= p && i+p <= len(frames) && equalFrames(frames[i:i+p], res[len(res)-p:]) {
+				i += p
+				continue
+			}
+			res = append(res, frames[i])
+			i++
+		}
+		frames = res
+	}
+	return frames
+}
+
+func equalFrames(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func dedupFile(workdir string) string {
+	return filepath.Join(workdir, "dedup")
+}
+
+// recordedDedupStrategy returns dedup strategy used with the workdir.
+func recordedDedupStrategy(workdir string) string {
+	data, err := ioutil.ReadFile(dedupFile(workdir))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Fatalf("failed to read dedup strategy: %v", err)
+		}
+		return "full"
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// recordDedupStrategy saves dedup strategy used with the workdir.
+func recordDedupStrategy(workdir string, ds dedupStrategy) {
+	if err := ioutil.WriteFile(dedupFile(workdir), []byte(ds.String()+"\n"), 0660); err != nil {
+		log.Fatalf("failed to write dedup strategy: %v", err)
+	}
+}
+
+// rekeySuppressions recomputes suppressions and crash buckets if the dedup strategy
+// has changed since the last run with this workdir, and records the new strategy.
+func (c *Coordinator) rekeySuppressions() {
+	cur := c.dedup.String()
+	old := recordedDedupStrategy(*flagWorkdir)
+	if old == cur {
+		return
+	}
+	log.Printf("dedup strategy changed from %v to %v, rekeying %v suppressions and %v buckets",
+		old, cur, len(c.suppressions.m), len(c.buckets))
+	supps, buckets := rekeyCrashes(c.dedup, c.crashers.dir, c.suppressions.m, c.buckets)
+	for sig := range c.buckets {
+		os.Remove(filepath.Join(bucketsDir(), bucketFilename(sig)))
+	}
+	for sig, a := range c.suppressions.m {
+		os.Remove(persistentFilename(c.suppressions.dir, a, sig))
+	}
+	c.suppressions.m = make(map[Sig]Artifact)
+	for _, supp := range supps {
+		c.suppressions.add(Artifact{supp, 0, false})
+	}
+	c.buckets = buckets
+	c.flushBuckets()
+	recordDedupStrategy(*flagWorkdir, c.dedup)
+}
+
+// rekeyCrashes recomputes suppressions and crash buckets with dedup strategy ds.
+// Signatures of crashes with a saved crasher in crashersDir are recomputed
+// from the crasher output. Buckets are rekeyed in place and marked dirty,
+// buckets that end up with the same signature are merged.
+func rekeyCrashes(ds dedupStrategy, crashersDir string, suppressions map[Sig]Artifact,
+	buckets map[Sig]*crashBucket) ([][]byte, map[Sig]*crashBucket) {
+	rekey := func(supp []byte, b *crashBucket) []byte {
+		if b != nil {
+			for _, name := range b.Inputs {
+				out, err := ioutil.ReadFile(filepath.Join(crashersDir, name+".output"))
+				if err == nil {
+					return extractSuppression(out, ds)
+				}
+			}
+		}
+		return ds.apply(supp)
+	}
+
+	buckets1 := make(map[Sig]*crashBucket)
+	for _, b := range buckets {
+		supp := rekey([]byte(b.Signature), b)
+		sig1 := hash(supp)
+		if b1 := buckets1[sig1]; b1 != nil {
+			mergeBuckets(b1, b)
+			continue
+		}
+		b.Signature = string(supp)
+		b.sig = sig1
+		b.dirty = true
+		buckets1[sig1] = b
+	}
+	var supps [][]byte
+	for sig, a := range suppressions {
+		supps = append(supps, rekey(a.data, buckets[sig]))
+	}
+	return supps, buckets1
+}
+
+// mergeBuckets merges bucket b into b1 when both map to the same signature.
+func mergeBuckets(b1, b *crashBucket) {
+	b1.Count += b.Count
+	if b.FirstSeen.Before(b1.FirstSeen) {
+		b1.FirstSeen = b.FirstSeen
+		b1.Worker = b.Worker
+		b1.BinHash = b.BinHash
+	}
+	if b.LastSeen.After(b1.LastSeen) {
+		b1.LastSeen = b.LastSeen
+	}
+	b1.Inputs = append(b1.Inputs, b.Inputs...)
+	b1.dirty = true
+}
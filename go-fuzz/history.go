@@ -0,0 +1,150 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Every stats snapshot is appended to workdir/stats.jsonl as a JSON line,
+// so that the history survives coordinator restarts and can be compared
+// between campaigns. It is served as /api/stats/history.
+
+// historyPoints is the max number of samples returned by /api/stats/history.
+const historyPoints = 1000
+
+type statsSample struct {
+	Time          time.Time
+	StartTime     time.Time // start of the coordinator run, changes on restart
+	Workers       uint64
+	Corpus        uint64
+	Crashers      uint64
+	Cover         uint64
+	Execs         uint64  // total during this run
+	ExecsPerSec   float64 // since the previous sample
+	Restarts      uint64
+	Slow          uint64 `json:",omitempty"`
+	Heavy         uint64 `json:",omitempty"`
+	Unstable      uint64 `json:",omitempty"`
+	Pending       uint64 `json:",omitempty"` // inputs queued on coordinator
+	TriageQueue   uint64 `json:",omitempty"` // inputs waiting for triage on workers
+	ExecTypes     map[string]uint64
+	CorpusOrigins map[string]uint64
+	SeedInputs    map[string]uint64 `json:",omitempty"` // new corpus inputs by -seeddir
+}
+
+func historyFile() string {
+	return filepath.Join(*flagWorkdir, "stats.jsonl")
+}
+
+// recordHistory appends current stats snapshot to the history file.
+func (c *Coordinator) recordHistory() {
+	stats := c.coordinatorStats()
+
+	c.mu.Lock()
+	s := &statsSample{
+		Time:          time.Now(),
+		StartTime:     stats.StartTime,
+		Workers:       stats.Workers,
+		Corpus:        stats.Corpus,
+		Crashers:      stats.Crashers,
+		Cover:         stats.Cover,
+		Execs:         stats.Execs,
+		Restarts:      c.statRestarts,
+		Slow:          stats.Slow,
+		Heavy:         stats.Heavy,
+		Unstable:      stats.Unstable,
+		Pending:       stats.Pending,
+		TriageQueue:   stats.TriageQueue,
+		ExecTypes:     make(map[string]uint64),
+		CorpusOrigins: make(map[string]uint64),
+	}
+	for typ := execType(0); typ < execTotal; typ++ {
+		s.ExecTypes[typ.String()] = c.statExecTypes[typ]
+		if c.statCorpusOrigins[typ] != 0 {
+			s.CorpusOrigins[typ.String()] = c.statCorpusOrigins[typ]
+		}
+	}
+	for _, sd := range c.seedDirs {
+		if s.SeedInputs == nil {
+			s.SeedInputs = make(map[string]uint64)
+		}
+		s.SeedInputs[sd.dir] = sd.inputs
+	}
+	prev := c.lastSample
+	c.lastSample = s
+	c.mu.Unlock()
+
+	if prev != nil && s.Execs >= prev.Execs {
+		if d := s.Time.Sub(prev.Time); d > 0 {
+			s.ExecsPerSec = float64(s.Execs-prev.Execs) / d.Seconds()
+		}
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		panic(err)
+	}
+	f, err := os.OpenFile(historyFile(), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0660)
+	if err != nil {
+		log.Printf("failed to open stats history: %v", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		log.Printf("failed to write stats history: %v", err)
+	}
+}
+
+// readHistory reads the history file thinning it out to at most max samples.
+// The last sample is always included.
+func readHistory(max int) ([]*statsSample, error) {
+	f, err := os.Open(historyFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	var samples []*statsSample
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		sample := new(statsSample)
+		if err := json.Unmarshal(s.Bytes(), sample); err != nil {
+			continue // partially written line
+		}
+		samples = append(samples, sample)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(samples) <= max {
+		return samples, nil
+	}
+	step := (len(samples) + max - 1) / max
+	var res []*statsSample
+	for i := (len(samples) - 1) % step; i < len(samples); i += step {
+		res = append(res, samples[i])
+	}
+	return res, nil
+}
+
+func (c *Coordinator) apiStatsHistory(w http.ResponseWriter, r *http.Request) {
+	samples, err := readHistory(historyPoints)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if samples == nil {
+		samples = []*statsSample{}
+	}
+	serveJSON(w, samples)
+}
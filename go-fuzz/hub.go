@@ -4,6 +4,7 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"net/rpc"
@@ -34,31 +35,45 @@ const (
 type Hub struct {
 	id          int
 	coordinator *rpc.Client
+	binHash     Sig
+	dedup       dedupStrategy
+	caps        capSet // protocol extensions negotiated with the coordinator
 
 	ro atomic.Value // *ROData
 
 	maxCoverMu sync.Mutex
 	maxCover   atomic.Value // []byte
 
-	initialTriage uint32
+	slowCoverMu sync.Mutex
+	slowCover   [2][]byte // max cover of slow and allocation-heavy inputs
+
+	initialTriage uint32 // initial corpus inputs that are not triaged yet
+	initialSync   bool   // initial corpus is still being received in Sync, see capStreamCorpus
+	paused        uint32 // fuzzing is paused by coordinator
 
 	corpusCoverSize int
 	corpusSigs      map[Sig]struct{}
 	corpusStale     bool
+	coverStale      bool // corpusCover has changed since last sync with the coordinator
 	triageQueue     []CoordinatorInput
+	resync          bool // inputs from coordinator were dropped, ask for corpus snapshot in the next sync
 
-	triageC     chan CoordinatorInput
-	newInputC   chan Input
-	newCrasherC chan NewCrasherArgs
-	syncC       chan Stats
+	triageC      chan CoordinatorInput
+	newInputC    chan Input
+	newCrasherC  chan NewCrasherArgs
+	newSlowC     chan NewSlowInputArgs
+	newUnstableC chan UnstableInput
+	syncC        chan Stats
 
 	stats         Stats
 	corpusOrigins [execCount]uint64
+	syncedOrigins [execCount]uint64 // corpusOrigins sent to coordinator
 }
 
 type ROData struct {
 	corpus       []Input
 	corpusCover  []byte
+	flaky        []byte // nonzero for cover blocks toggled by unstable inputs, nil if none
 	badInputs    map[Sig]struct{}
 	suppressions map[Sig]struct{}
 	strLits      [][]byte // string literals in testee
@@ -69,22 +84,25 @@ type ROData struct {
 }
 
 type Stats struct {
-	execs    uint64
-	restarts uint64
+	execs       uint64
+	restarts    uint64
+	crashes     map[Sig]uint64 // hits of already known crashes by suppression signature
+	execTypes   [execCount]uint64
+	execTime    [execTimeBuckets]uint64 // histogram of exec times, see execTimeBounds
+	execTimeSum uint64
 }
 
-func newHub(metadata MetaData) *Hub {
+func newHub(metadata MetaData, binHash Sig) *Hub {
 	procs := *flagProcs
 	hub := &Hub{
-		corpusSigs:  make(map[Sig]struct{}),
-		triageC:     make(chan CoordinatorInput, procs),
-		newInputC:   make(chan Input, procs),
-		newCrasherC: make(chan NewCrasherArgs, procs),
-		syncC:       make(chan Stats, procs),
-	}
-
-	if err := hub.connect(); err != nil {
-		log.Fatalf("failed to connect to coordinator: %v", err)
+		binHash:      binHash,
+		corpusSigs:   make(map[Sig]struct{}),
+		triageC:      make(chan CoordinatorInput, procs),
+		newInputC:    make(chan Input, procs),
+		newCrasherC:  make(chan NewCrasherArgs, procs),
+		newSlowC:     make(chan NewSlowInputArgs, procs),
+		newUnstableC: make(chan UnstableInput, procs),
+		syncC:        make(chan Stats, procs),
 	}
 
 	coverBlocks := make(map[int][]CoverBlock)
@@ -100,6 +118,8 @@ func newHub(metadata MetaData) *Hub {
 		sonarSites[i].loc = fmt.Sprintf("%v:%v.%v,%v.%v", b.File, b.StartLine, b.StartCol, b.EndLine, b.EndCol)
 	}
 	hub.maxCover.Store(make([]byte, CoverSize))
+	hub.slowCover[0] = make([]byte, CoverSize)
+	hub.slowCover[1] = make([]byte, CoverSize)
 
 	ro := &ROData{
 		corpusCover:  make([]byte, CoverSize),
@@ -118,35 +138,56 @@ func newHub(metadata MetaData) *Hub {
 	}
 	hub.ro.Store(ro)
 
+	if err := hub.connect(); err != nil {
+		log.Fatalf("failed to connect to coordinator: %v", err)
+	}
 	go hub.loop()
 
 	return hub
 }
 
 func (hub *Hub) connect() error {
-	var c *rpc.Client
-	var err error
-
-	t := time.Now()
-	for {
-		c, err = rpc.Dial("tcp", *flagWorker)
-		if err == nil || time.Since(t) > *flagConnectionTimeout {
-			break
-		}
-		time.Sleep(connectionPollInterval)
-	}
+	c, err := dialCoordinator(*flagWorker, useTLS())
 	if err != nil {
 		return err
 	}
 	var res ConnectRes
-	if err := c.Call("Coordinator.Connect", &ConnectArgs{Procs: *flagProcs}, &res); err != nil {
+	args := &ConnectArgs{
+		Procs:      *flagProcs,
+		BinHash:    hub.binHash,
+		Version:    protocolVersion,
+		MinVersion: minProtocolVersion,
+		Caps:       capabilities,
+	}
+	if err := c.Call("Coordinator.Connect", args, &res); err != nil {
+		c.Close()
+		return err
+	}
+	if err := checkCoordinatorProtocol(res.Version); err != nil {
+		c.Close()
 		return err
 	}
 
+	dedup, err := parseDedupStrategy(res.Dedup)
+	if err != nil {
+		c.Close()
+		return err
+	}
 	hub.coordinator = c
 	hub.id = res.ID
-	hub.initialTriage = uint32(len(res.Corpus))
-	hub.triageQueue = res.Corpus
+	hub.dedup = dedup
+	hub.caps = makeCapSet(res.Caps)
+	hub.resync = false // coordinator sends the whole corpus on connect
+	if hub.caps[capStreamCorpus] {
+		// Initial corpus comes in Sync, hold workers until all of it is received.
+		hub.initialSync = true
+		atomic.StoreUint32(&hub.initialTriage, 1)
+	} else {
+		atomic.StoreUint32(&hub.initialTriage, uint32(len(res.Corpus)))
+		hub.triageQueue = res.Corpus
+	}
+	hub.setPaused(res.Paused)
+	hub.addSuppressions(res.Suppressions)
 	return nil
 }
 
@@ -156,6 +197,10 @@ func (hub *Hub) loop() {
 	var triageInput CoordinatorInput
 
 	syncTicker := time.NewTicker(syncPeriod).C
+	// Don't wait for the ticker to get the first batch of initial corpus.
+	if hub.initialSync && !hub.sync() {
+		return
+	}
 	for {
 		if len(hub.triageQueue) > 0 && triageC == nil {
 			n := len(hub.triageQueue) - 1
@@ -168,37 +213,8 @@ func (hub *Hub) loop() {
 		select {
 		case <-syncTicker:
 			// Sync with the coordinator.
-			if *flagV >= 1 {
-				ro := hub.ro.Load().(*ROData)
-				log.Printf("hub: corpus=%v bootstrap=%v fuzz=%v minimize=%v versifier=%v smash=%v sonar=%v",
-					len(ro.corpus), hub.corpusOrigins[execBootstrap]+hub.corpusOrigins[execCorpus],
-					hub.corpusOrigins[execFuzz]+hub.corpusOrigins[execSonar],
-					hub.corpusOrigins[execMinimizeInput]+hub.corpusOrigins[execMinimizeCrasher],
-					hub.corpusOrigins[execVersifier], hub.corpusOrigins[execSmash],
-					hub.corpusOrigins[execSonarHint])
-			}
-			args := &SyncArgs{
-				ID:            hub.id,
-				Execs:         hub.stats.execs,
-				Restarts:      hub.stats.restarts,
-				CoverFullness: hub.corpusCoverSize,
-			}
-			hub.stats.execs = 0
-			hub.stats.restarts = 0
-			var res SyncRes
-			if err := hub.coordinator.Call("Coordinator.Sync", args, &res); err != nil {
-				log.Printf("sync call failed: %v, reconnection to coordinator", err)
-				if err := hub.connect(); err != nil {
-					log.Printf("failed to connect to coordinator: %v, killing worker", err)
-					return
-				}
-			}
-			if len(res.Inputs) > 0 {
-				hub.triageQueue = append(hub.triageQueue, res.Inputs...)
-			}
-			if hub.corpusStale {
-				hub.updateScores()
-				hub.corpusStale = false
+			if !hub.sync() {
+				return
 			}
 
 		case triageC <- triageInput:
@@ -217,105 +233,396 @@ func (hub *Hub) loop() {
 			// Sync from a worker.
 			hub.stats.execs += s.execs
 			hub.stats.restarts += s.restarts
-
-		case input := <-hub.newInputC:
-			// New interesting input from workers.
-			ro := hub.ro.Load().(*ROData)
-			if !compareCover(ro.corpusCover, input.cover) {
-				break
-			}
-			sig := hash(input.data)
-			if _, ok := hub.corpusSigs[sig]; ok {
-				break
-			}
-
-			// Passed deduplication, taking it.
-			if *flagV >= 2 {
-				log.Printf("hub received new input [%v]%v mine=%v", len(input.data), hash(input.data), input.mine)
-			}
-			hub.corpusSigs[sig] = struct{}{}
-			ro1 := new(ROData)
-			*ro1 = *ro
-			// Assign it the default score, but mark corpus for score recalculation.
-			hub.corpusStale = true
-			scoreSum := 0
-			if len(ro1.corpus) > 0 {
-				scoreSum = ro1.corpus[len(ro1.corpus)-1].runningScoreSum
-			}
-			input.score = defScore
-			input.runningScoreSum = scoreSum + defScore
-			ro1.corpus = append(ro1.corpus, input)
-			hub.updateMaxCover(input.cover)
-			ro1.corpusCover = makeCopy(ro.corpusCover)
-			hub.corpusCoverSize = updateMaxCover(ro1.corpusCover, input.cover)
-			if input.res > 0 || input.typ == execBootstrap {
-				ro1.verse = versifier.BuildVerse(ro.verse, input.data)
-			}
-			hub.ro.Store(ro1)
-			hub.corpusOrigins[input.typ]++
-
-			if input.mine {
-				if err := hub.coordinator.Call("Coordinator.NewInput", NewInputArgs{hub.id, input.data, uint64(input.depth)}, nil); err != nil {
-					log.Printf("new input call failed: %v, reconnecting to coordinator", err)
-					if err := hub.connect(); err != nil {
-						log.Printf("failed to connect to coordinator: %v, killing worker", err)
-						return
-					}
+			for sig, n := range s.crashes {
+				if hub.stats.crashes == nil {
+					hub.stats.crashes = make(map[Sig]uint64)
 				}
+				hub.stats.crashes[sig] += n
 			}
+			addCounters(hub.stats.execTypes[:], s.execTypes[:])
+			addCounters(hub.stats.execTime[:], s.execTime[:])
+			hub.stats.execTimeSum += s.execTimeSum
 
-			if *flagDumpCover {
-				dumpCover(filepath.Join(*flagWorkdir, "coverprofile"), ro.coverBlocks, ro.corpusCover)
+		case input := <-hub.newInputC:
+			// New interesting input from workers.
+			if !hub.addInput(input) {
+				return
 			}
 
 		case crash := <-hub.newCrasherC:
 			// New crasher from workers. Woohoo!
-			if crash.Hanging || !*flagDup {
+			// Hanging and out-of-memory inputs are too expensive to execute again.
+			if crash.Hanging || crash.OutOfMemory {
 				ro := hub.ro.Load().(*ROData)
 				ro1 := new(ROData)
 				*ro1 = *ro
-				if crash.Hanging {
-					ro1.badInputs = make(map[Sig]struct{})
-					for k, v := range ro.badInputs {
-						ro1.badInputs[k] = v
-					}
-					ro1.badInputs[hash(crash.Data)] = struct{}{}
-				}
-				if !*flagDup {
-					ro1.suppressions = make(map[Sig]struct{})
-					for k, v := range ro.suppressions {
-						ro1.suppressions[k] = v
-					}
-					ro1.suppressions[hash(crash.Suppression)] = struct{}{}
+				ro1.badInputs = make(map[Sig]struct{})
+				for k, v := range ro.badInputs {
+					ro1.badInputs[k] = v
 				}
+				ro1.badInputs[hash(crash.Data)] = struct{}{}
 				hub.ro.Store(ro1)
 			}
+			if !*flagDup {
+				hub.addSuppressions([]Sig{hash(crash.Suppression)})
+			}
+			crash.ID = hub.id
+			crash.BinHash = hub.binHash
 			if err := hub.coordinator.Call("Coordinator.NewCrasher", crash, nil); err != nil {
 				log.Printf("new crasher call failed: %v", err)
 			}
+
+		case u := <-hub.newUnstableC:
+			// Input with nondeterministic coverage or result from workers.
+			var blocks []int
+			flaky := hub.ro.Load().(*ROData).flaky
+			for _, id := range u.blocks {
+				if flaky == nil || flaky[id] == 0 {
+					blocks = append(blocks, id)
+				}
+			}
+			if len(blocks) == 0 && !u.res {
+				break // Already know about these blocks.
+			}
+			if len(blocks) != 0 {
+				hub.markFlaky(blocks)
+			}
+			ro := hub.ro.Load().(*ROData)
+			var desc bytes.Buffer
+			if u.res {
+				fmt.Fprintf(&desc, "%v\n", unstableResultDesc)
+			}
+			for _, id := range u.blocks {
+				for _, b := range ro.coverBlocks[id] {
+					fmt.Fprintf(&desc, "%v:%v.%v,%v.%v\n", b.File, b.StartLine, b.StartCol, b.EndLine, b.EndCol)
+				}
+			}
+			if *flagV >= 1 {
+				log.Printf("hub: unstable input [%v]%v: %v new flaky blocks, result differs: %v", len(u.data), hash(u.data), len(blocks), u.res)
+			}
+			if err := hub.coordinator.Call("Coordinator.NewUnstableInput", NewUnstableInputArgs{u.data, desc.Bytes()}, nil); err != nil {
+				log.Printf("new unstable input call failed: %v", err)
+			}
+
+		case slow := <-hub.newSlowC:
+			// New slow or allocation-heavy input from workers.
+			if err := hub.coordinator.Call("Coordinator.NewSlowInput", slow, nil); err != nil {
+				log.Printf("new slow input call failed: %v", err)
+			}
+		}
+	}
+}
+
+// sync sends stats to the coordinator and receives new inputs.
+// Returns false if connection to coordinator is lost.
+func (hub *Hub) sync() bool {
+	if *flagV >= 1 {
+		ro := hub.ro.Load().(*ROData)
+		log.Printf("hub: corpus=%v bootstrap=%v fuzz=%v minimize=%v versifier=%v smash=%v sonar=%v",
+			len(ro.corpus), hub.corpusOrigins[execBootstrap]+hub.corpusOrigins[execCorpus],
+			hub.corpusOrigins[execFuzz]+hub.corpusOrigins[execSonar],
+			hub.corpusOrigins[execMinimizeInput]+hub.corpusOrigins[execMinimizeCrasher],
+			hub.corpusOrigins[execVersifier], hub.corpusOrigins[execSmash],
+			hub.corpusOrigins[execSonarHint])
+	}
+	var origins [execCount]uint64
+	for i, n := range hub.corpusOrigins {
+		origins[i] = n - hub.syncedOrigins[i]
+	}
+	hub.syncedOrigins = hub.corpusOrigins
+	sonarSites, sonarSitesHit := hub.sonarStats()
+	stats := hub.stats
+	hub.stats = Stats{}
+	args := &SyncArgs{
+		ID:            hub.id,
+		Execs:         stats.execs,
+		Restarts:      stats.restarts,
+		CoverFullness: hub.corpusCoverSize,
+		Crashes:       stats.crashes,
+		TriageQueue:   len(hub.triageQueue),
+		Resync:        hub.resync,
+	}
+	if hub.caps[capExecStats] {
+		args.ExecTypes = stats.execTypes[:]
+		args.CorpusOrigins = origins[:]
+		args.ExecTime = stats.execTime[:]
+		args.ExecTimeSum = stats.execTimeSum
+		args.SonarSites = sonarSites
+		args.SonarSitesHit = sonarSitesHit
+	}
+	if hub.coverStale && hub.caps[capCorpusCover] {
+		args.CorpusCover = hub.ro.Load().(*ROData).corpusCover
+		hub.coverStale = false
+	}
+	var res SyncRes
+	if err := hub.coordinator.Call("Coordinator.Sync", args, &res); err != nil {
+		log.Printf("sync call failed: %v, reconnection to coordinator", err)
+		if err := hub.connect(); err != nil {
+			log.Printf("failed to connect to coordinator: %v, killing worker", err)
+			return false
+		}
+		return true
+	}
+	hub.resync = false
+	overflow := 0
+	for _, inp := range res.Inputs {
+		// Corpus snapshots sent on resync mostly contain inputs we already have.
+		if _, ok := hub.corpusSigs[hash(inp.Data)]; ok && inp.Resync {
+			continue
+		}
+		// Inputs with coverage from workers with the same binary don't need triage.
+		if input, ok := hub.coveredInput(inp); ok {
+			hub.addInput(input)
+			continue
+		}
+		// Coordinator sends only as many inputs as fit into the queue,
+		// but don't trust it to bound our memory consumption.
+		if len(hub.triageQueue) >= maxTriageQueue {
+			overflow++
+			continue
+		}
+		if hub.initialSync && inp.Resync {
+			atomic.AddUint32(&hub.initialTriage, 1)
+		}
+		hub.triageQueue = append(hub.triageQueue, inp)
+	}
+	if overflow != 0 {
+		// The dropped inputs won't be sent again, so ask for the whole corpus.
+		log.Printf("hub: triage queue is full, dropped %v inputs from coordinator, requesting resync", overflow)
+		hub.resync = true
+	}
+	if hub.initialSync && res.ResyncLeft == 0 {
+		// All initial corpus is received, workers can start fuzzing once it is triaged.
+		hub.initialSync = false
+		hub.initialTriageDone()
+	}
+	if len(res.Drop) > 0 {
+		hub.dropInputs(res.Drop)
+	}
+	hub.setPaused(res.Paused)
+	hub.addSuppressions(res.Suppressions)
+	if hub.corpusStale {
+		hub.updateScores()
+		hub.corpusStale = false
+	}
+	return true
+}
+
+// addSuppressions makes workers skip crashes with the suppression signatures.
+func (hub *Hub) addSuppressions(sigs []Sig) {
+	if len(sigs) == 0 {
+		return
+	}
+	ro := hub.ro.Load().(*ROData)
+	ro1 := new(ROData)
+	*ro1 = *ro
+	ro1.suppressions = make(map[Sig]struct{})
+	for k, v := range ro.suppressions {
+		ro1.suppressions[k] = v
+	}
+	for _, sig := range sigs {
+		ro1.suppressions[sig] = struct{}{}
+	}
+	hub.ro.Store(ro1)
+}
+
+// initialTriageDone is called when an initial corpus input is triaged.
+func (hub *Hub) initialTriageDone() {
+	for {
+		x := atomic.LoadUint32(&hub.initialTriage)
+		if x == 0 || atomic.CompareAndSwapUint32(&hub.initialTriage, x, x-1) {
+			break
+		}
+	}
+}
+
+// addInput adds new interesting input to corpus if it still gives new coverage,
+// and sends it to coordinator if it was found by one of our workers.
+// Returns false if connection to coordinator is lost.
+func (hub *Hub) addInput(input Input) bool {
+	ro := hub.ro.Load().(*ROData)
+	if !compareCover(ro.corpusCover, input.cover, ro.flaky) {
+		return true
+	}
+	sig := hash(input.data)
+	if _, ok := hub.corpusSigs[sig]; ok {
+		return true
+	}
+
+	// Passed deduplication, taking it.
+	if *flagV >= 2 {
+		log.Printf("hub received new input [%v]%v mine=%v", len(input.data), hash(input.data), input.mine)
+	}
+	hub.corpusSigs[sig] = struct{}{}
+	ro1 := new(ROData)
+	*ro1 = *ro
+	// Assign it the default score, but mark corpus for score recalculation.
+	hub.corpusStale = true
+	scoreSum := 0
+	if len(ro1.corpus) > 0 {
+		scoreSum = ro1.corpus[len(ro1.corpus)-1].runningScoreSum
+	}
+	input.score = defScore
+	input.runningScoreSum = scoreSum + defScore
+	ro1.corpus = append(ro1.corpus, input)
+	hub.updateMaxCover(input.cover)
+	ro1.corpusCover = makeCopy(ro.corpusCover)
+	hub.corpusCoverSize = updateMaxCover(ro1.corpusCover, input.cover, ro1.flaky)
+	hub.coverStale = true
+	if input.res > 0 || input.typ == execBootstrap {
+		ro1.verse = versifier.BuildVerse(ro.verse, input.data)
+	}
+	hub.ro.Store(ro1)
+	hub.corpusOrigins[input.typ]++
+
+	if input.mine {
+		args := NewInputArgs{ID: hub.id, Data: input.data, Prio: uint64(input.depth)}
+		if hub.caps[capInputCover] {
+			args.Cover = &InputCover{hub.binHash, compressCover(input.cover), input.execTime, input.res}
+		}
+		if err := hub.coordinator.Call("Coordinator.NewInput", args, nil); err != nil {
+			log.Printf("new input call failed: %v, reconnecting to coordinator", err)
+			if err := hub.connect(); err != nil {
+				log.Printf("failed to connect to coordinator: %v, killing worker", err)
+				return false
+			}
+		}
+	}
+
+	if *flagDumpCover {
+		dumpCover(filepath.Join(*flagWorkdir, "coverprofile"), ro.coverBlocks, ro.corpusCover)
+	}
+	return true
+}
+
+// coveredInput converts coordinator input with known coverage into corpus input.
+func (hub *Hub) coveredInput(inp CoordinatorInput) (Input, bool) {
+	if inp.Cover == nil || inp.Cover.BinHash != hub.binHash || len(inp.Data) > MaxInputSize {
+		return Input{}, false
+	}
+	cover, err := decompressCover(inp.Cover.Cover)
+	if err != nil {
+		log.Printf("input [%v]%v: %v", len(inp.Data), hash(inp.Data), err)
+		return Input{}, false
+	}
+	input := Input{
+		data:     inp.Data,
+		cover:    cover,
+		res:      inp.Cover.Res,
+		depth:    int(inp.Prio),
+		typ:      inp.Type,
+		execTime: inp.Cover.ExecTime,
+	}
+	for _, v := range cover {
+		if v != 0 {
+			input.coverSize++
 		}
 	}
+	return input, true
 }
 
 // Preliminary cover update to prevent new input thundering herd.
 // This function is synchronous to reduce latency.
 func (hub *Hub) updateMaxCover(cover []byte) bool {
+	flaky := hub.ro.Load().(*ROData).flaky
 	oldMaxCover := hub.maxCover.Load().([]byte)
-	if !compareCover(oldMaxCover, cover) {
+	if !compareCover(oldMaxCover, cover, flaky) {
 		return false
 	}
 	hub.maxCoverMu.Lock()
 	defer hub.maxCoverMu.Unlock()
 	oldMaxCover = hub.maxCover.Load().([]byte)
-	if !compareCover(oldMaxCover, cover) {
+	if !compareCover(oldMaxCover, cover, flaky) {
 		return false
 	}
 	maxCover := makeCopy(oldMaxCover)
-	updateMaxCover(maxCover, cover)
+	updateMaxCover(maxCover, cover, flaky)
 	hub.maxCover.Store(maxCover)
 	return true
 }
 
+// markFlaky excludes flaky cover blocks from new coverage decisions.
+// Coverage of the blocks is left intact, so it is not reported to coordinator.
+func (hub *Hub) markFlaky(blocks []int) {
+	ro := hub.ro.Load().(*ROData)
+	ro1 := new(ROData)
+	*ro1 = *ro
+	if ro.flaky == nil {
+		ro1.flaky = make([]byte, CoverSize)
+	} else {
+		ro1.flaky = makeCopy(ro.flaky)
+	}
+	for _, id := range blocks {
+		ro1.flaky[id] = 1
+	}
+	hub.ro.Store(ro1)
+}
+
+func (hub *Hub) setPaused(paused bool) {
+	v := uint32(0)
+	if paused {
+		v = 1
+	}
+	if atomic.SwapUint32(&hub.paused, v) != v && *flagV >= 1 {
+		log.Printf("hub: paused=%v", paused)
+	}
+}
+
+// dropInputs removes inputs from corpus and triage queue.
+// Dropped inputs stay in corpusSigs, so that they are not taken again.
+func (hub *Hub) dropInputs(sigs []Sig) {
+	drop := make(map[Sig]bool)
+	for _, sig := range sigs {
+		drop[sig] = true
+	}
+	queue := hub.triageQueue[:0]
+	for _, inp := range hub.triageQueue {
+		if !drop[hash(inp.Data)] {
+			queue = append(queue, inp)
+		}
+	}
+	hub.triageQueue = queue
+
+	ro := hub.ro.Load().(*ROData)
+	ro1 := new(ROData)
+	*ro1 = *ro
+	ro1.corpus = nil
+	scoreSum := 0
+	for _, inp := range ro.corpus {
+		if drop[hash(inp.data)] {
+			continue
+		}
+		scoreSum += inp.score
+		inp.runningScoreSum = scoreSum
+		ro1.corpus = append(ro1.corpus, inp)
+	}
+	if len(ro1.corpus) == len(ro.corpus) {
+		return
+	}
+	hub.ro.Store(ro1)
+	hub.corpusStale = len(ro1.corpus) != 0
+	if *flagV >= 1 {
+		log.Printf("hub: dropped %v corpus inputs", len(ro.corpus)-len(ro1.corpus))
+	}
+}
+
+// updateSlowCover is updateMaxCover for slow (heavy=false) and allocation-heavy inputs.
+// Only inputs with new coverage are reported, otherwise we would
+// report every mutation of the same slow input.
+func (hub *Hub) updateSlowCover(heavy bool, cover []byte) bool {
+	idx := 0
+	if heavy {
+		idx = 1
+	}
+	hub.slowCoverMu.Lock()
+	defer hub.slowCoverMu.Unlock()
+	if !compareCover(hub.slowCover[idx], cover, nil) {
+		return false
+	}
+	updateMaxCover(hub.slowCover[idx], cover, nil)
+	return true
+}
+
 func (hub *Hub) updateScores() {
 	ro := hub.ro.Load().(*ROData)
 	ro1 := new(ROData)
@@ -456,3 +763,17 @@ func (hub *Hub) updateScores() {
 
 	hub.ro.Store(ro1)
 }
+
+// sonarStats returns total number of sonar sites and number of sites that were executed.
+func (hub *Hub) sonarStats() (total, hit int) {
+	ro := hub.ro.Load().(*ROData)
+	for i := range ro.sonarSites {
+		site := &ro.sonarSites[i]
+		site.Lock()
+		if site.takenTotal[0]+site.takenTotal[1] != 0 {
+			hit++
+		}
+		site.Unlock()
+	}
+	return len(ro.sonarSites), hit
+}
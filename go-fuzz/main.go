@@ -13,6 +13,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
@@ -29,23 +31,50 @@ var (
 	flagWorkdir           = flag.String("workdir", ".", "dir with persistent work data")
 	flagProcs             = flag.Int("procs", runtime.NumCPU(), "parallelism level")
 	flagTimeout           = flag.Int("timeout", 10, "test timeout, in seconds")
+	flagMemLimit          = flag.Int("memlimit", 0, "memory a single test execution can allocate, in megabytes (0 means no limit)")
+	flagSlow              = flag.Duration("slow", 0, "save inputs that execute longer than this into workdir/slow (0 means disabled)")
+	flagAlloc             = flag.Int("alloc", 0, "save inputs that allocate more than this many megabytes into workdir/heavy (0 means disabled)")
+	flagLeaks             = flag.Duration("leaks", 0, "report goroutines that the fuzz function leaves running for longer than this (0 means disabled)")
 	flagMinimize          = flag.Duration("minimize", 1*time.Minute, "time limit for input minimization")
 	flagCoordinator       = flag.String("coordinator", "", "coordinator mode (value is coordinator address)")
 	flagWorker            = flag.String("worker", "", "worker mode (value is coordinator address)")
 	flagConnectionTimeout = flag.Duration("connectiontimeout", 1*time.Minute, "time limit for worker to try to connect coordinator")
+	flagTLSCert           = flag.String("tlscert", "", "PEM certificate for TLS connection between coordinator and workers")
+	flagTLSKey            = flag.String("tlskey", "", "PEM private key for -tlscert")
+	flagTLSCA             = flag.String("tlsca", "", "PEM CA certificates to verify coordinator (worker mode) or worker (coordinator mode) certificates")
+	flagTLSServerName     = flag.String("tlsservername", "", "expected name in coordinator certificate (default is host from -worker)")
+	flagTLSInsecure       = flag.Bool("tlsinsecure", false, "accept workers without client certificates if -tlsca is not set (coordinator mode only)")
 	flagBin               = flag.String("bin", "", "test binary built with go-fuzz-build")
 	flagFunc              = flag.String("func", "", "function to fuzz")
 	flagDumpCover         = flag.Bool("dumpcover", false, "dump coverage profile into workdir")
 	flagDup               = flag.Bool("dup", false, "collect duplicate crashers")
+	flagDedup             = flag.String("dedup", "full", "crash deduplication strategy: full or comma-separated list of top=N, collapse, noruntime, nomsg (coordinator and -merge modes only)")
+	flagSeedDirs          = newStringsFlag("seeddir", "dir that is polled for new inputs during fuzzing, can be repeated (coordinator mode only)")
+	flagSyncDir           = flag.String("syncdir", "", "AFL-style sync dir to share corpus with other fuzzers (coordinator mode only)")
+	flagSyncName          = flag.String("syncname", "go-fuzz", "name of this fuzzer in -syncdir")
+	flagUpstream          = flag.String("upstream", "", "address of upstream coordinator to forward inputs, crashers and stats to (coordinator mode only)")
+	flagSuppress          = flag.String("suppress", "", "JSON file with crash suppression rules, reloaded on change (coordinator mode only)")
+	flagRegress           = flag.Bool("regress", false, "re-run crashers against -bin, move fixed ones to workdir/crashers/fixed and exit with status 1 if any still crash")
+	flagRegressCorpus     = flag.Bool("regresscorpus", false, "also run corpus inputs in -regress mode")
+	flagMerge             = flag.String("merge", "", "merge crashers, suppressions and corpus of workdirs given as arguments into this workdir, corpus inputs are re-triaged against -bin")
 	flagTestOutput        = flag.Bool("testoutput", false, "print test binary output to stdout (for debugging only)")
 	flagCoverCounters     = flag.Bool("covercounters", true, "use coverage hit counters")
 	flagSonar             = flag.Bool("sonar", true, "use sonar hints")
 	flagV                 = flag.Int("v", 0, "verbosity level")
 	flagHTTP              = flag.String("http", "", "HTTP server listen address (coordinator mode only)")
+	flagControlToken      = flag.String("controltoken", "", "token that enables remote control via /api/control/ http endpoints (coordinator mode only)")
+	flagDuration          = flag.Duration("duration", 0, "stop fuzzing after this time (0 means run until interrupted, coordinator mode only)")
+	flagMaxExecs          = flag.Uint64("maxexecs", 0, "stop fuzzing after this number of executions (0 means no limit, coordinator mode only)")
+	flagPlateau           = flag.Duration("plateau", 0, "stop fuzzing when no new inputs or coverage appear for this long (0 means disabled, coordinator mode only)")
+	flagPlateauSmash      = flag.Bool("plateausmash", false, "on -plateau, first smash all corpus inputs once more and stop only if that does not help")
+	flagJUnit             = flag.String("junit", "", "write JUnit XML report of crashes to this file on shutdown (coordinator mode only)")
+	flagSARIF             = flag.String("sarif", "", "write SARIF report of crashes to this file on shutdown (coordinator mode only)")
 
 	shutdown        uint32
 	shutdownC       = make(chan struct{})
+	shutdownMu      sync.Mutex
 	shutdownCleanup []func()
+	exitStatus      int32 // process exit status on shutdown
 )
 
 func main() {
@@ -56,19 +85,15 @@ func main() {
 	if *flagHTTP != "" && *flagWorker != "" {
 		log.Fatalf("both -http and -worker are specified")
 	}
+	if *flagUpstream != "" && *flagWorker != "" {
+		log.Fatalf("both -upstream and -worker are specified")
+	}
 
 	go func() {
 		c := make(chan os.Signal, 1)
 		signal.Notify(c, syscall.SIGINT)
 		<-c
-		atomic.StoreUint32(&shutdown, 1)
-		close(shutdownC)
-		log.Printf("shutting down...")
-		time.Sleep(2 * time.Second)
-		for _, f := range shutdownCleanup {
-			f()
-		}
-		os.Exit(0)
+		shutdownProcess()
 	}()
 
 	runtime.GOMAXPROCS(min(*flagProcs, runtime.NumCPU()))
@@ -78,6 +103,23 @@ func main() {
 	*flagWorkdir = expandHomeDir(*flagWorkdir)
 	*flagBin = expandHomeDir(*flagBin)
 
+	if *flagRegress {
+		if *flagWorkdir == "" {
+			log.Fatalf("-workdir is not set")
+		}
+		if *flagBin == "" {
+			*flagBin = defaultBin()
+		}
+		regressMain()
+	}
+
+	if *flagMerge != "" {
+		if *flagBin == "" {
+			*flagBin = defaultBin()
+		}
+		mergeMain()
+	}
+
 	if *flagCoordinator != "" || *flagWorker == "" {
 		if *flagWorkdir == "" {
 			log.Fatalf("-workdir is not set")
@@ -89,33 +131,32 @@ func main() {
 		if err != nil {
 			log.Fatalf("failed to listen: %v", err)
 		}
+		if useTLS() {
+			ln = coordinatorTLSListener(ln)
+		}
+		if *flagUpstream != "" && *flagBin == "" {
+			// Workers of the subtree must run the same binary as upstream.
+			*flagBin = fetchBin(*flagUpstream, tlsConfigured())
+		}
 		if *flagCoordinator == "localhost:0" && *flagWorker == "" {
 			*flagWorker = ln.Addr().String()
+			if *flagBin == "" {
+				*flagBin = defaultBin()
+			}
 		}
 		go coordinatorMain(ln)
 	}
 
 	if *flagWorker != "" {
 		if *flagBin == "" {
-			// Try the default. Best effort only.
-			var bin string
-			cfg := new(packages.Config)
-			// Note that we do not set GO111MODULE here in order to respect any GO111MODULE 
-			// setting by the user as we are finding dependencies. See modules support 
-			// comments in go-fuzz-build/main.go for more details.
-			cfg.Env = os.Environ()
-			pkgs, err := packages.Load(cfg, ".")
-			if err == nil && len(pkgs) == 1 {
-				bin = pkgs[0].Name + "-fuzz.zip"
-				_, err := os.Stat(bin)
-				if err != nil {
-					bin = ""
-				}
-			}
-			if bin == "" {
-				log.Fatalf("-bin is not set")
-			}
-			*flagBin = bin
+			*flagBin = findDefaultBin()
+		}
+		if *flagBin == "" && *flagCoordinator == "" {
+			// Remote worker without local binary, try to get it from coordinator.
+			*flagBin = fetchBin(*flagWorker, useTLS())
+		}
+		if *flagBin == "" {
+			log.Fatalf("-bin is not set")
 		}
 		go workerMain()
 	}
@@ -123,6 +164,59 @@ func main() {
 	select {}
 }
 
+// defaultBin looks for the default -bin archive for the package in the current dir
+// and fails if there is none.
+func defaultBin() string {
+	bin := findDefaultBin()
+	if bin == "" {
+		log.Fatalf("-bin is not set")
+	}
+	return bin
+}
+
+// findDefaultBin looks for the default -bin archive for the package in the current dir.
+// Best effort only, returns "" if not found.
+func findDefaultBin() string {
+	var bin string
+	cfg := new(packages.Config)
+	// Note that we do not set GO111MODULE here in order to respect any GO111MODULE 
+	// setting by the user as we are finding dependencies. See modules support 
+	// comments in go-fuzz-build/main.go for more details.
+	cfg.Env = os.Environ()
+	pkgs, err := packages.Load(cfg, ".")
+	if err == nil && len(pkgs) == 1 {
+		bin = pkgs[0].Name + "-fuzz.zip"
+		_, err := os.Stat(bin)
+		if err != nil {
+			bin = ""
+		}
+	}
+	return bin
+}
+
+// onShutdown registers f to be called on process shutdown.
+func onShutdown(f func()) {
+	shutdownMu.Lock()
+	defer shutdownMu.Unlock()
+	shutdownCleanup = append(shutdownCleanup, f)
+}
+
+// shutdownProcess stops fuzzing, runs cleanup functions and exits with exitStatus.
+// Only the first call has effect, subsequent calls return immediately.
+func shutdownProcess() {
+	if !atomic.CompareAndSwapUint32(&shutdown, 0, 1) {
+		return
+	}
+	close(shutdownC)
+	log.Printf("shutting down...")
+	time.Sleep(2 * time.Second)
+	shutdownMu.Lock()
+	for _, f := range shutdownCleanup {
+		f()
+	}
+	os.Exit(int(atomic.LoadInt32(&exitStatus)))
+}
+
 // expandHomeDir expands the tilde sign and replaces it
 // with current users home directory and returns it.
 func expandHomeDir(path string) string {
@@ -132,3 +226,21 @@ func expandHomeDir(path string) string {
 	}
 	return path
 }
+
+// stringsFlag is a flag that can be specified multiple times.
+type stringsFlag []string
+
+func newStringsFlag(name, usage string) *stringsFlag {
+	f := new(stringsFlag)
+	flag.Var(f, name, usage)
+	return f
+}
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
@@ -0,0 +1,223 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"text/tabwriter"
+
+	. "github.com/dvyukov/go-fuzz/go-fuzz-defs"
+)
+
+// mergeSource is a workdir that is merged into -merge workdir.
+type mergeSource struct {
+	dir string
+
+	crashers, newCrashers         int
+	suppressions, newSuppressions int
+	buckets, newBuckets           int
+	corpus, newCorpus             int
+	crashing                      int // corpus inputs that crash
+}
+
+// mergeMain merges crashers, suppressions, crash buckets and corpus
+// of workdirs given as arguments into -merge workdir. Corpus inputs are
+// re-triaged against -bin and only inputs that add coverage are taken.
+// Suppressions and buckets of workdirs that were fuzzed with a different
+// dedup strategy are rekeyed to -dedup strategy.
+func mergeMain() {
+	dst := expandHomeDir(*flagMerge)
+	var sources []*mergeSource
+	for _, dir := range flag.Args() {
+		dir = expandHomeDir(dir)
+		if st, err := os.Stat(dir); err != nil || !st.IsDir() {
+			log.Fatalf("merge source %v is not a dir", dir)
+		}
+		sources = append(sources, &mergeSource{dir: dir})
+	}
+	if len(sources) == 0 {
+		log.Fatalf("no workdirs to merge, usage: go-fuzz -bin=... -merge=dst src1 src2 ...")
+	}
+	*flagWorkdir = dst
+	ds, err := parseDedupStrategy(*flagDedup)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	crashers := newCrashersSet(filepath.Join(dst, "crashers"))
+	suppressions := newPersistentSet(filepath.Join(dst, "suppressions"))
+	c := &Coordinator{
+		crashers:     crashers,
+		suppressions: suppressions,
+		buckets:      loadBuckets(bucketsDir()),
+		dedup:        ds,
+	}
+	c.rekeySuppressions()
+	for _, src := range sources {
+		for _, a := range readPersistentSet(filepath.Join(src.dir, "crashers"), fixedCrashersDir).m {
+			src.crashers++
+			if !crashers.add(Artifact{a.data, 0, false}) {
+				continue
+			}
+			src.newCrashers++
+			sig := hash(a.data)
+			for _, typ := range []string{"output", "quoted"} {
+				desc, err := ioutil.ReadFile(filepath.Join(src.dir, "crashers", hex.EncodeToString(sig[:])+"."+typ))
+				if err == nil {
+					crashers.addDescription(a.data, desc, typ)
+				}
+			}
+		}
+		srcSupps := readPersistentSet(filepath.Join(src.dir, "suppressions"), "").m
+		buckets := loadBuckets(filepath.Join(src.dir, "buckets"))
+		var supps [][]byte
+		if old := recordedDedupStrategy(src.dir); old != ds.String() {
+			log.Printf("%v uses dedup strategy %v, rekeying its suppressions and buckets to %v", src.dir, old, ds)
+			supps, buckets = rekeyCrashes(ds, filepath.Join(src.dir, "crashers"), srcSupps, buckets)
+		} else {
+			for _, a := range srcSupps {
+				supps = append(supps, a.data)
+			}
+		}
+		for _, supp := range supps {
+			src.suppressions++
+			if suppressions.add(Artifact{supp, 0, false}) {
+				src.newSuppressions++
+			}
+		}
+		for sig, b := range buckets {
+			src.buckets++
+			b1 := c.buckets[sig]
+			if b1 == nil {
+				src.newBuckets++
+				b.dirty = true
+				c.buckets[sig] = b
+				continue
+			}
+			mergeBuckets(b1, b)
+			b1.Inputs = uniqueStrings(b1.Inputs)
+		}
+	}
+	c.flushBuckets()
+	recordDedupStrategy(dst, ds)
+
+	mergeCorpus(dst, sources)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "workdir\tcrashers\tsuppressions\tbuckets\tcorpus\tcrashing\t\n")
+	for _, src := range sources {
+		fmt.Fprintf(w, "%v\t%v/%v\t%v/%v\t%v/%v\t%v/%v\t%v\t\n", src.dir,
+			src.newCrashers, src.crashers, src.newSuppressions, src.suppressions,
+			src.newBuckets, src.buckets, src.newCorpus, src.corpus, src.crashing)
+	}
+	w.Flush()
+	fmt.Printf("(new/total, merged into %v: %v crashers, %v suppressions, %v buckets)\n",
+		dst, len(crashers.m), len(suppressions.m), len(c.buckets))
+	os.Exit(0)
+}
+
+// mergeCorpus runs the existing dst corpus and then corpus of every source
+// against -bin and adds source inputs that give new coverage to dst corpus.
+func mergeCorpus(dst string, sources []*mergeSource) {
+	coverBin, _, _, fnidx, cleanup := loadBin()
+	onShutdown(cleanup)
+	defer cleanup()
+
+	corpus := newPersistentSet(filepath.Join(dst, "corpus"))
+	maxCover := make([]byte, CoverSize)
+	var mu sync.Mutex
+	run := func(inputs []Artifact, add func(a Artifact, cover []byte)) {
+		// Smaller inputs go first, so that they are preferred over larger ones with the same coverage.
+		sort.Slice(inputs, func(i, j int) bool { return len(inputs[i].data) < len(inputs[j].data) })
+		inputC := make(chan Artifact)
+		var wg sync.WaitGroup
+		for i := 0; i < *flagProcs; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var stats Stats
+				bin := newTestBinary(coverBin, func() {}, &stats, fnidx)
+				defer bin.close()
+				for a := range inputC {
+					_, _, _, cover, _, _, crashed, _, _ := bin.test(a.data)
+					if crashed {
+						cover = nil
+					}
+					mu.Lock()
+					add(a, cover)
+					mu.Unlock()
+				}
+			}()
+		}
+		for _, a := range inputs {
+			inputC <- a
+		}
+		close(inputC)
+		wg.Wait()
+	}
+
+	var inputs []Artifact
+	for _, a := range corpus.m {
+		inputs = append(inputs, a)
+	}
+	run(inputs, func(a Artifact, cover []byte) {
+		if cover != nil {
+			updateMaxCover(maxCover, cover, nil)
+		}
+	})
+	for _, src := range sources {
+		inputs = nil
+		for _, a := range readPersistentSet(filepath.Join(src.dir, "corpus"), "").m {
+			inputs = append(inputs, a)
+		}
+		src.corpus = len(inputs)
+		run(inputs, func(a Artifact, cover []byte) {
+			if cover == nil {
+				src.crashing++
+				return
+			}
+			if !compareCover(maxCover, cover, nil) {
+				return
+			}
+			updateMaxCover(maxCover, cover, nil)
+			if corpus.add(Artifact{a.data, a.meta, false}) {
+				src.newCorpus++
+			}
+		})
+	}
+}
+
+// readPersistentSet reads set from dir without creating the dir if it does not exist.
+// Subdirs named skipDir are not read if it is set.
+func readPersistentSet(dir, skipDir string) *PersistentSet {
+	ps := &PersistentSet{
+		dir:     dir,
+		skipDir: skipDir,
+		m:       make(map[Sig]Artifact),
+	}
+	if _, err := os.Stat(dir); err == nil {
+		ps.readInDir(dir)
+	}
+	return ps
+}
+
+func uniqueStrings(list []string) []string {
+	seen := make(map[string]bool)
+	res := list[:0]
+	for _, s := range list {
+		if !seen[s] {
+			seen[s] = true
+			res = append(res, s)
+		}
+	}
+	return res
+}
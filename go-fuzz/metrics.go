@@ -0,0 +1,146 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// execTimeBounds are upper bounds (in ns) of exec time histogram buckets,
+// the last bucket is unbounded.
+var execTimeBounds = [execTimeBuckets - 1]uint64{1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10}
+
+const execTimeBuckets = 8
+
+func (s *Stats) noteExecTime(ns uint64) {
+	i := 0
+	for i < len(execTimeBounds) && ns > execTimeBounds[i] {
+		i++
+	}
+	s.execTime[i]++
+	s.execTimeSum += ns
+}
+
+// addCounters adds src counters to dst, extra elements in src are ignored.
+func addCounters(dst, src []uint64) {
+	for i := 0; i < len(dst) && i < len(src); i++ {
+		dst[i] += src[i]
+	}
+}
+
+// metrics serves coordinator statistics in Prometheus text exposition format.
+func (c *Coordinator) metrics(w http.ResponseWriter, r *http.Request) {
+	stats := c.coordinatorStats()
+
+	c.mu.Lock()
+	execTypes := c.statExecTypes
+	origins := c.statCorpusOrigins
+	execTime := c.statExecTime
+	execTimeSum := c.statExecTimeSum
+	restarts := c.statRestarts
+	resyncs := c.statResyncs
+	sonarSites, sonarSitesHit := c.sonarSites, c.sonarSitesHit
+	var syncExports uint64
+	var syncFuzzers []string
+	syncInputs := make(map[string]uint64)
+	if c.syncDir != nil {
+		syncExports = c.syncDir.exports
+		for fuzzer, n := range c.syncDir.inputs {
+			syncFuzzers = append(syncFuzzers, fuzzer)
+			syncInputs[fuzzer] = n
+		}
+		sort.Strings(syncFuzzers)
+	}
+	seedInputs := make([]uint64, len(c.seedDirs))
+	for i, sd := range c.seedDirs {
+		seedInputs[i] = sd.inputs
+	}
+	var crashes uint64
+	for _, b := range c.buckets {
+		crashes += b.Count
+	}
+	c.mu.Unlock()
+
+	buf := new(bytes.Buffer)
+	metric := func(name, typ, help string) {
+		fmt.Fprintf(buf, "# HELP gofuzz_%v %v\n# TYPE gofuzz_%v %v\n", name, help, name, typ)
+	}
+	value := func(name string, v interface{}) {
+		fmt.Fprintf(buf, "gofuzz_%v %v\n", name, v)
+	}
+	metric("execs_total", "counter", "Number of test executions.")
+	value("execs_total", stats.Execs)
+	metric("restarts_total", "counter", "Number of test process restarts.")
+	value("restarts_total", restarts)
+	metric("exec_type_execs_total", "counter", "Number of test executions by type.")
+	for typ := execType(0); typ < execTotal; typ++ {
+		value(fmt.Sprintf("exec_type_execs_total{type=%q}", typ), execTypes[typ])
+	}
+	metric("exec_time_seconds", "histogram", "Duration of successful test executions.")
+	var cum uint64
+	for i, n := range execTime {
+		cum += n
+		le := "+Inf"
+		if i < len(execTimeBounds) {
+			le = fmt.Sprint(float64(execTimeBounds[i]) / 1e9)
+		}
+		value(fmt.Sprintf("exec_time_seconds_bucket{le=%q}", le), cum)
+	}
+	value("exec_time_seconds_sum", float64(execTimeSum)/1e9)
+	value("exec_time_seconds_count", cum)
+	metric("workers", "gauge", "Number of connected worker processes (test procs).")
+	value("workers", stats.Workers)
+	metric("corpus_inputs", "gauge", "Number of inputs in corpus.")
+	value("corpus_inputs", stats.Corpus)
+	metric("corpus_origin_inputs_total", "counter", "Number of new corpus inputs found by workers by exec type.")
+	for typ := execType(0); typ < execTotal; typ++ {
+		value(fmt.Sprintf("corpus_origin_inputs_total{type=%q}", typ), origins[typ])
+	}
+	if len(seedInputs) != 0 {
+		metric("seed_dir_inputs_total", "counter", "Number of new corpus inputs taken from -seeddir by dir.")
+		for i, n := range seedInputs {
+			value(fmt.Sprintf("seed_dir_inputs_total{dir=%q}", c.seedDirs[i].dir), n)
+		}
+	}
+	if *flagSyncDir != "" {
+		metric("sync_exported_inputs_total", "counter", "Number of corpus inputs exported to -syncdir.")
+		value("sync_exported_inputs_total", syncExports)
+		metric("sync_imported_inputs_total", "counter", "Number of new corpus inputs imported from -syncdir by fuzzer.")
+		for _, fuzzer := range syncFuzzers {
+			value(fmt.Sprintf("sync_imported_inputs_total{fuzzer=%q}", fuzzer), syncInputs[fuzzer])
+		}
+	}
+	metric("pending_inputs", "gauge", "Number of inputs queued on coordinator for sending to workers.")
+	value("pending_inputs", stats.Pending)
+	metric("triage_queue_inputs", "gauge", "Number of inputs waiting for triage on workers.")
+	value("triage_queue_inputs", stats.TriageQueue)
+	metric("worker_resyncs_total", "counter", "Number of times a worker fell behind and was resynced with corpus snapshot.")
+	value("worker_resyncs_total", resyncs)
+	metric("crashers", "gauge", "Number of saved crashers.")
+	value("crashers", stats.Crashers)
+	metric("crashes_total", "counter", "Number of crash hits, including known crashes.")
+	value("crashes_total", crashes)
+	metric("slow_inputs", "gauge", "Number of saved slow and allocation-heavy inputs.")
+	value(`slow_inputs{type="slow"}`, stats.Slow)
+	value(`slow_inputs{type="heavy"}`, stats.Heavy)
+	metric("unstable_inputs", "gauge", "Number of saved unstable inputs.")
+	value("unstable_inputs", stats.Unstable)
+	metric("cover", "gauge", "Number of covered blocks.")
+	value("cover", stats.Cover)
+	metric("sonar_sites", "gauge", "Number of sonar (comparison) sites.")
+	value("sonar_sites", sonarSites)
+	metric("sonar_sites_hit", "gauge", "Number of sonar sites executed at least once.")
+	value("sonar_sites_hit", sonarSitesHit)
+	metric("uptime_seconds", "gauge", "Time since coordinator start.")
+	value("uptime_seconds", int64(time.Since(stats.StartTime).Seconds()))
+	metric("last_new_input_timestamp_seconds", "gauge", "Unix time of the last new corpus input.")
+	value("last_new_input_timestamp_seconds", stats.LastNewInputTime.Unix())
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(buf.Bytes())
+}
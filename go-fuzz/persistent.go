@@ -16,8 +16,10 @@ import (
 
 // PersistentSet is a set of binary blobs with a persistent mirror on disk.
 type PersistentSet struct {
-	dir string
-	m   map[Sig]Artifact
+	dir     string
+	skipDir string // name of subdirs that are not loaded, if set
+	m       map[Sig]Artifact
+	files   map[Sig][]string // other files of artifacts (user-provided, duplicate and description files)
 }
 
 type Artifact struct {
@@ -28,10 +30,26 @@ type Artifact struct {
 
 type Sig [sha1.Size]byte
 
+// fixedCrashersDir is a subdir of crashers where regress mode moves crashers
+// that do not reproduce anymore, they are not loaded by newCrashersSet.
+const fixedCrashersDir = "fixed"
+
 func hash(data []byte) Sig {
 	return Sig(sha1.Sum(data))
 }
 
+// parseSig parses hex-encoded Sig.
+func parseSig(name string) (Sig, bool) {
+	var sig Sig
+	if len(name) != hex.EncodedLen(len(sig)) {
+		return sig, false
+	}
+	if _, err := hex.Decode(sig[:], []byte(name)); err != nil {
+		return sig, false
+	}
+	return sig, true
+}
+
 func newPersistentSet(dir string) *PersistentSet {
 	ps := &PersistentSet{
 		dir: dir,
@@ -42,6 +60,18 @@ func newPersistentSet(dir string) *PersistentSet {
 	return ps
 }
 
+// newCrashersSet is newPersistentSet for crashers, it skips fixed crashers.
+func newCrashersSet(dir string) *PersistentSet {
+	ps := &PersistentSet{
+		dir:     dir,
+		skipDir: fixedCrashersDir,
+		m:       make(map[Sig]Artifact),
+	}
+	os.MkdirAll(dir, 0770)
+	ps.readInDir(dir)
+	return ps
+}
+
 func (ps *PersistentSet) readInDir(dir string) {
 	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -49,6 +79,18 @@ func (ps *PersistentSet) readInDir(dir string) {
 			return nil
 		}
 		if info.IsDir() {
+			if path != dir && ps.skipDir != "" && info.Name() == ps.skipDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		name := info.Name()
+		const hexLen = 2 * sha1.Size
+		if len(name) > hexLen+1 && isHexString(name[:hexLen]) && name[hexLen] == '.' {
+			// Description file.
+			if sig, ok := parseSig(name[:hexLen]); ok {
+				ps.noteFile(sig, path)
+			}
 			return nil
 		}
 		data, err := ioutil.ReadFile(path)
@@ -58,23 +100,36 @@ func (ps *PersistentSet) readInDir(dir string) {
 		}
 		sig := hash(data)
 		if _, ok := ps.m[sig]; ok {
+			ps.noteFile(sig, path)
 			return nil
 		}
-		name := info.Name()
-		const hexLen = 2 * sha1.Size
-		if len(name) > hexLen+1 && isHexString(name[:hexLen]) && name[hexLen] == '.' {
-			return nil // description file
-		}
 		var meta uint64
 		if len(name) > hexLen+1 && isHexString(name[:hexLen]) && name[hexLen] == '-' {
 			meta, _ = strconv.ParseUint(name[2*sha1.Size+1:], 10, 64)
 		}
 		a := Artifact{data, meta, len(name) < hexLen || !isHexString(name[:hexLen])}
 		ps.m[sig] = a
+		if path != persistentFilename(ps.dir, a, sig) {
+			ps.noteFile(sig, path)
+		}
 		return nil
 	})
 }
 
+// noteFile records a file of the artifact that is not named by persistentFilename,
+// so that remove does not need to look for it.
+func (ps *PersistentSet) noteFile(sig Sig, path string) {
+	for _, f := range ps.files[sig] {
+		if f == path {
+			return
+		}
+	}
+	if ps.files == nil {
+		ps.files = make(map[Sig][]string)
+	}
+	ps.files[sig] = append(ps.files[sig], path)
+}
+
 func persistentFilename(dir string, a Artifact, sig Sig) string {
 	fname := filepath.Join(dir, hex.EncodeToString(sig[:]))
 	if a.meta != 0 {
@@ -106,6 +161,27 @@ func (ps *PersistentSet) add(a Artifact) bool {
 	return true
 }
 
+// remove deletes the artifact and its description files from the set and disk.
+func (ps *PersistentSet) remove(sig Sig) bool {
+	a, ok := ps.m[sig]
+	if !ok {
+		return false
+	}
+	delete(ps.m, sig)
+	os.Remove(persistentFilename(ps.dir, a, sig))
+	for _, f := range ps.files[sig] {
+		os.Remove(f)
+	}
+	delete(ps.files, sig)
+	return true
+}
+
+// readDescription returns contents of the complementary file created by addDescription.
+func (ps *PersistentSet) readDescription(sig Sig, typ string) []byte {
+	data, _ := ioutil.ReadFile(filepath.Join(ps.dir, fmt.Sprintf("%v.%v", hex.EncodeToString(sig[:]), typ)))
+	return data
+}
+
 // addDescription creates a complementary to data file on disk.
 func (ps *PersistentSet) addDescription(data []byte, desc []byte, typ string) {
 	sig := hash(data)
@@ -113,4 +189,5 @@ func (ps *PersistentSet) addDescription(data []byte, desc []byte, typ string) {
 	if err := ioutil.WriteFile(fname, desc, 0660); err != nil {
 		log.Printf("failed to write file: %v", err)
 	}
+	ps.noteFile(sig, fname)
 }
@@ -0,0 +1,91 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Version of the coordinator-worker RPC protocol exchanged in Connect.
+// It must be bumped on incompatible changes of RPC types (e.g. changed
+// meaning of a field), compatible extensions are negotiated with capabilities.
+// Worker sends the range of versions it supports, coordinator rejects it
+// if the range does not overlap with the range coordinator supports and
+// otherwise replies with the newest version supported by both, which both
+// sides use from then on. Version 0 is sent by go-fuzz versions that predate
+// versioning.
+const (
+	protocolVersion    = 1
+	minProtocolVersion = 1 // oldest version that is still supported
+)
+
+// Optional protocol extensions. Worker sends the set of capabilities it supports
+// in ConnectArgs, coordinator replies with the subset it supports too,
+// and both sides use only the extensions from the subset.
+const (
+	capExecStats    = "execstats"    // per exec type stats, exec time histogram and sonar stats in SyncArgs
+	capCorpusCover  = "corpuscover"  // corpus coverage bitmap in SyncArgs
+	capControl      = "control"      // remote control (pause and drop) in SyncRes
+	capInputCover   = "inputcover"   // coverage of new inputs in NewInputArgs and CoordinatorInput
+	capStreamCorpus = "streamcorpus" // initial corpus is sent in SyncRes instead of ConnectRes
+	capSuppressions = "suppressions" // suppressions of crashes that workers should not report in ConnectRes and SyncRes
+)
+
+// capabilities are the extensions supported by this version.
+var capabilities = []string{capExecStats, capCorpusCover, capControl, capInputCover, capStreamCorpus, capSuppressions}
+
+type capSet map[string]bool
+
+func makeCapSet(caps []string) capSet {
+	set := make(capSet)
+	for _, c := range caps {
+		set[c] = true
+	}
+	return set
+}
+
+// negotiateCaps returns capabilities supported by both sides.
+func negotiateCaps(peer []string) []string {
+	var res []string
+	ours := makeCapSet(capabilities)
+	for c := range makeCapSet(peer) {
+		if ours[c] {
+			res = append(res, c)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// checkWorkerProtocol returns protocol version to use with worker
+// that supports protocol versions from minVersion to version.
+func checkWorkerProtocol(version, minVersion int) (int, error) {
+	if version < minProtocolVersion {
+		return 0, fmt.Errorf("worker speaks protocol version %v, but coordinator requires at least version %v, update go-fuzz on the worker",
+			version, minProtocolVersion)
+	}
+	if minVersion > protocolVersion {
+		return 0, fmt.Errorf("worker requires protocol version %v, but coordinator speaks only version %v, update go-fuzz on the coordinator",
+			minVersion, protocolVersion)
+	}
+	if version > protocolVersion {
+		return protocolVersion, nil
+	}
+	return version, nil
+}
+
+// checkCoordinatorProtocol says whether worker can use protocol version
+// that coordinator has chosen in Connect.
+func checkCoordinatorProtocol(version int) error {
+	if version == 0 {
+		return errors.New("coordinator does not support protocol versioning, update go-fuzz on the coordinator")
+	}
+	if version < minProtocolVersion || version > protocolVersion {
+		return fmt.Errorf("coordinator chose protocol version %v, but worker speaks only versions %v-%v",
+			version, minProtocolVersion, protocolVersion)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckWorkerProtocol(t *testing.T) {
+	tests := []struct {
+		version    int
+		minVersion int
+		res        int // negotiated version, 0 if the worker is rejected
+	}{
+		{protocolVersion, minProtocolVersion, protocolVersion},
+		{protocolVersion, protocolVersion, protocolVersion},
+		{protocolVersion + 1, protocolVersion, protocolVersion},
+		{protocolVersion + 5, minProtocolVersion, protocolVersion},
+		{minProtocolVersion, minProtocolVersion, minProtocolVersion},
+		{protocolVersion + 2, protocolVersion + 1, 0},
+		{minProtocolVersion - 1, minProtocolVersion - 1, 0},
+		{0, 0, 0},
+	}
+	for _, test := range tests {
+		res, err := checkWorkerProtocol(test.version, test.minVersion)
+		if test.res == 0 {
+			if err == nil {
+				t.Errorf("worker versions %v-%v: got version %v, want error", test.minVersion, test.version, res)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("worker versions %v-%v: got error %v, want version %v", test.minVersion, test.version, err, test.res)
+			continue
+		}
+		if res != test.res {
+			t.Errorf("worker versions %v-%v: got version %v, want %v", test.minVersion, test.version, res, test.res)
+		}
+		if err := checkCoordinatorProtocol(res); err != nil {
+			t.Errorf("worker versions %v-%v: worker rejects negotiated version %v: %v", test.minVersion, test.version, res, err)
+		}
+	}
+	for _, version := range []int{0, minProtocolVersion - 1, protocolVersion + 1} {
+		if err := checkCoordinatorProtocol(version); err == nil {
+			t.Errorf("worker accepts coordinator version %v", version)
+		}
+	}
+}
+
+func TestNegotiateCaps(t *testing.T) {
+	tests := []struct {
+		peer []string
+		res  []string
+	}{
+		{nil, nil},
+		{[]string{"unknown"}, nil},
+		{[]string{capControl, "unknown", capExecStats}, []string{capControl, capExecStats}},
+		{[]string{capInputCover, capInputCover}, []string{capInputCover}},
+		{capabilities, []string{capControl, capCorpusCover, capExecStats, capInputCover, capStreamCorpus, capSuppressions}},
+	}
+	for _, test := range tests {
+		res := negotiateCaps(test.peer)
+		if !reflect.DeepEqual(res, test.res) {
+			t.Errorf("peer caps %q: got %q, want %q", test.peer, res, test.res)
+		}
+	}
+}
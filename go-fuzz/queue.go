@@ -0,0 +1,88 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"log"
+)
+
+// Coordinator sends a worker only as many inputs as fit into the worker triage
+// queue (up to maxTriageQueue) and maxSyncBytes. If a worker falls too far behind,
+// its pending queue is replaced with a snapshot of corpus signatures (resync),
+// inputs are looked up in corpus when they are sent.
+const (
+	maxPendingInputs = 10000    // max length of worker pending queue before resync
+	maxTriageQueue   = 5000     // max length of hub triage queue
+	maxSyncBytes     = 32 << 20 // max size of inputs sent in a single Sync
+)
+
+// queueInput queues input for sending to the worker.
+// Must be called with c.mu held.
+func (c *Coordinator) queueInput(w *CoordinatorWorker, inp CoordinatorInput) {
+	if len(w.pending) < maxPendingInputs {
+		w.pending = append(w.pending, inp)
+		return
+	}
+	if w.resync != nil {
+		// Resync is in progress, send the input as part of the snapshot.
+		w.resync = append(w.resync, hash(inp.Data))
+		return
+	}
+	log.Printf("worker %v is %v inputs behind, resyncing it with corpus snapshot", w.id, len(w.pending))
+	// The input is already in corpus, so the snapshot includes it.
+	c.resyncWorker(w, false)
+	c.statResyncs++
+}
+
+// resyncWorker replaces worker pending queue with a snapshot of the whole corpus.
+// If smash is set, the worker smashes all snapshot inputs again.
+// Must be called with c.mu held.
+func (c *Coordinator) resyncWorker(w *CoordinatorWorker, smash bool) {
+	w.pending = nil
+	w.resync = corpusSnapshot(c.corpus)
+	w.resmash = smash
+}
+
+// corpusSnapshot returns signatures of all inputs in corpus.
+func corpusSnapshot(corpus *PersistentSet) []Sig {
+	res := make([]Sig, 0, len(corpus.m))
+	for sig := range corpus.m {
+		res = append(res, sig)
+	}
+	return res
+}
+
+// takeInputs returns the next batch of inputs for the worker.
+// Must be called with c.mu held.
+func (c *Coordinator) takeInputs(w *CoordinatorWorker) []CoordinatorInput {
+	var res []CoordinatorInput
+	size := 0
+	for len(res) < maxTriageQueue-w.triageQueue && size < maxSyncBytes {
+		var inp CoordinatorInput
+		if n := len(w.resync); n != 0 {
+			sig := w.resync[n-1]
+			w.resync = w.resync[:n-1]
+			a, ok := c.corpus.m[sig]
+			if !ok {
+				continue // dropped
+			}
+			inp = CoordinatorInput{a.data, a.meta, execCorpus, !a.user, !w.resmash, nil, true}
+		} else if len(w.pending) != 0 {
+			inp = w.pending[0]
+			w.pending[0] = CoordinatorInput{}
+			w.pending = w.pending[1:]
+		} else {
+			break
+		}
+		res = append(res, inp)
+		size += len(inp.Data)
+	}
+	if len(w.resync) == 0 {
+		w.resync = nil
+	}
+	if len(w.pending) == 0 {
+		w.pending = nil
+	}
+	return res
+}
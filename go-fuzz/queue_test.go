@@ -0,0 +1,139 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"testing"
+)
+
+func testCorpus(n int) *PersistentSet {
+	ps := &PersistentSet{m: make(map[Sig]Artifact)}
+	for i := 0; i < n; i++ {
+		data := []byte(fmt.Sprintf("input%v", i))
+		ps.m[hash(data)] = Artifact{data, 0, false}
+	}
+	return ps
+}
+
+func TestQueueInputResync(t *testing.T) {
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(ioutil.Discard)
+	c := &Coordinator{corpus: testCorpus(100)}
+	w := &CoordinatorWorker{}
+	for i := 0; i < maxPendingInputs; i++ {
+		c.queueInput(w, CoordinatorInput{Data: []byte{byte(i)}})
+	}
+	if len(w.pending) != maxPendingInputs || w.resync != nil || c.statResyncs != 0 {
+		t.Fatalf("pending queue is not full: pending %v, resync %v", len(w.pending), len(w.resync))
+	}
+	// Overflow replaces pending queue with corpus snapshot.
+	c.queueInput(w, CoordinatorInput{Data: []byte("input0")})
+	if w.pending != nil || len(w.resync) != len(c.corpus.m) || c.statResyncs != 1 {
+		t.Fatalf("no resync on overflow: pending %v, resync %v, resyncs %v",
+			len(w.pending), len(w.resync), c.statResyncs)
+	}
+	// Overflow during resync appends inputs to the snapshot instead of another resync.
+	for i := 0; i < maxPendingInputs; i++ {
+		c.queueInput(w, CoordinatorInput{Data: []byte{byte(i)}})
+	}
+	c.queueInput(w, CoordinatorInput{Data: []byte("new")})
+	if len(w.pending) != maxPendingInputs || len(w.resync) != len(c.corpus.m)+1 || c.statResyncs != 1 {
+		t.Fatalf("bad queue during resync: pending %v, resync %v, resyncs %v",
+			len(w.pending), len(w.resync), c.statResyncs)
+	}
+	if w.resync[len(w.resync)-1] != hash([]byte("new")) {
+		t.Fatalf("queued input is not in resync")
+	}
+}
+
+func TestTakeInputsResync(t *testing.T) {
+	c := &Coordinator{corpus: testCorpus(100)}
+	w := &CoordinatorWorker{}
+	c.resyncWorker(w, false)
+	// Inputs dropped from corpus after the snapshot is taken are skipped.
+	dropped := make(map[Sig]bool)
+	for sig := range c.corpus.m {
+		if len(dropped) == 10 {
+			break
+		}
+		dropped[sig] = true
+		delete(c.corpus.m, sig)
+	}
+	inputs := c.takeInputs(w)
+	if len(inputs) != len(c.corpus.m) {
+		t.Fatalf("got %v inputs, want %v", len(inputs), len(c.corpus.m))
+	}
+	for _, inp := range inputs {
+		if dropped[hash(inp.Data)] {
+			t.Fatalf("got dropped input %q", inp.Data)
+		}
+		if !inp.Resync || !inp.Smashed || !inp.Minimized {
+			t.Fatalf("bad resync input %+v", inp)
+		}
+	}
+	if w.resync != nil {
+		t.Fatalf("resync is not finished: %v inputs left", len(w.resync))
+	}
+	// Resmash resync asks the worker to smash inputs again.
+	c.resyncWorker(w, true)
+	for _, inp := range c.takeInputs(w) {
+		if inp.Smashed {
+			t.Fatalf("resmash input is marked as smashed")
+		}
+	}
+}
+
+func TestTakeInputsTriageQueue(t *testing.T) {
+	c := &Coordinator{corpus: testCorpus(0)}
+	w := &CoordinatorWorker{}
+	for i := 0; i < maxTriageQueue+100; i++ {
+		w.pending = append(w.pending, CoordinatorInput{Data: []byte{byte(i)}})
+	}
+	tests := []struct {
+		triageQueue int
+		inputs      int
+	}{
+		{maxTriageQueue, 0},
+		{maxTriageQueue + 10, 0},
+		{maxTriageQueue - 10, 10},
+		{0, maxTriageQueue},
+		{0, 90},
+		{0, 0},
+	}
+	for i, test := range tests {
+		w.triageQueue = test.triageQueue
+		if inputs := c.takeInputs(w); len(inputs) != test.inputs {
+			t.Errorf("#%v: triage queue %v: got %v inputs, want %v", i, test.triageQueue, len(inputs), test.inputs)
+		}
+	}
+	if w.pending != nil {
+		t.Errorf("%v inputs left in pending queue", len(w.pending))
+	}
+}
+
+func TestSyncResync(t *testing.T) {
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(ioutil.Discard)
+	c := &Coordinator{corpus: testCorpus(100)}
+	w := &CoordinatorWorker{id: 1}
+	c.workers = map[int]*CoordinatorWorker{w.id: w}
+	var res SyncRes
+	if err := c.Sync(&SyncArgs{ID: w.id}, &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Inputs) != 0 {
+		t.Fatalf("got %v inputs without resync", len(res.Inputs))
+	}
+	// Worker that dropped inputs gets the whole corpus.
+	if err := c.Sync(&SyncArgs{ID: w.id, Resync: true}, &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Inputs) != len(c.corpus.m) || res.ResyncLeft != 0 || c.statResyncs != 1 {
+		t.Fatalf("got %v inputs, %v left, %v resyncs, want %v inputs",
+			len(res.Inputs), res.ResyncLeft, c.statResyncs, len(c.corpus.m))
+	}
+}
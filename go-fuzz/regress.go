@@ -0,0 +1,156 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// regressRuns is the number of times a crasher is re-run before it is considered fixed.
+const regressRuns = 3
+
+type regressResult int
+
+const (
+	regressFixed     regressResult = iota // does not crash anymore
+	regressSame                           // crashes with the same signature
+	regressDifferent                      // crashes with a different signature
+)
+
+type regressJob struct {
+	name   string
+	data   []byte
+	supp   []byte // expected suppression signature, nil if unknown
+	res    regressResult
+	output []byte
+}
+
+// regressMain re-runs all crashers (and corpus inputs with -regresscorpus)
+// against -bin, moves fixed crashers to workdir/crashers/fixed
+// and exits with status 1 if any input still crashes.
+func regressMain() {
+	coverBin, _, _, fnidx, cleanup := loadBin()
+	onShutdown(cleanup)
+	ds, err := parseDedupStrategy(recordedDedupStrategy(*flagWorkdir))
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	crashersDir := filepath.Join(*flagWorkdir, "crashers")
+	var jobs []*regressJob
+	for sig, a := range newCrashersSet(crashersDir).m {
+		name := hex.EncodeToString(sig[:])
+		job := &regressJob{name: name, data: a.data}
+		if out, err := ioutil.ReadFile(filepath.Join(crashersDir, name+".output")); err == nil {
+			job.supp = extractSuppression(out, ds)
+		}
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].name < jobs[j].name })
+	ncrashers := len(jobs)
+	if *flagRegressCorpus {
+		for sig, a := range newPersistentSet(filepath.Join(*flagWorkdir, "corpus")).m {
+			jobs = append(jobs, &regressJob{name: hex.EncodeToString(sig[:]), data: a.data})
+		}
+	}
+
+	jobC := make(chan *regressJob)
+	var wg sync.WaitGroup
+	for i := 0; i < *flagProcs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var stats Stats
+			bin := newTestBinary(coverBin, func() {}, &stats, fnidx)
+			defer bin.close()
+			for job := range jobC {
+				runRegressJob(bin, job, ds)
+			}
+		}()
+	}
+	for _, job := range jobs {
+		jobC <- job
+	}
+	close(jobC)
+	wg.Wait()
+	cleanup()
+
+	var fixed, same, different, corpus int
+	for i, job := range jobs {
+		if i >= ncrashers {
+			if job.res != regressFixed {
+				corpus++
+				log.Printf("corpus input %v crashes: %s", job.name, parseCrash(job.output).msg)
+			}
+			continue
+		}
+		switch job.res {
+		case regressFixed:
+			fixed++
+			log.Printf("crasher %v is fixed", job.name)
+			moveFixedCrasher(crashersDir, job.name)
+		case regressSame:
+			same++
+			log.Printf("crasher %v still crashes: %s", job.name, parseCrash(job.output).msg)
+		case regressDifferent:
+			different++
+			log.Printf("crasher %v crashes differently: was %s, now %s",
+				job.name, firstLine(job.supp), parseCrash(job.output).msg)
+		}
+	}
+	log.Printf("regress: crashers: %v, fixed: %v, same: %v, different: %v", ncrashers, fixed, same, different)
+	if *flagRegressCorpus {
+		log.Printf("regress: corpus: %v, crashing: %v", len(jobs)-ncrashers, corpus)
+	}
+	if same+different+corpus != 0 {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func runRegressJob(bin *TestBinary, job *regressJob, ds dedupStrategy) {
+	for i := 0; i < regressRuns; i++ {
+		_, _, _, _, _, output, crashed, _, _ := bin.test(job.data)
+		if !crashed {
+			continue
+		}
+		job.output = output
+		job.res = regressSame
+		if job.supp != nil && !bytes.Equal(extractSuppression(output, ds), job.supp) {
+			job.res = regressDifferent
+		}
+		return
+	}
+	job.res = regressFixed
+}
+
+// moveFixedCrasher moves crasher with all description files to crashers/fixed.
+func moveFixedCrasher(dir, name string) {
+	fixedDir := filepath.Join(dir, fixedCrashersDir)
+	os.MkdirAll(fixedDir, 0770)
+	files, err := filepath.Glob(filepath.Join(dir, name+"*"))
+	if err != nil {
+		log.Printf("failed to list crasher files: %v", err)
+		return
+	}
+	for _, f := range files {
+		if err := os.Rename(f, filepath.Join(fixedDir, filepath.Base(f))); err != nil {
+			log.Printf("failed to move fixed crasher: %v", err)
+		}
+	}
+}
+
+func firstLine(data []byte) []byte {
+	if idx := bytes.IndexByte(data, '\n'); idx != -1 {
+		data = data[:idx]
+	}
+	return data
+}
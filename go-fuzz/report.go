@@ -0,0 +1,368 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"archive/zip"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/dvyukov/go-fuzz/internal/go-fuzz-types"
+)
+
+// ciMode says if fuzzing has a time or executions budget,
+// in which case exit status reflects crashers found during the run.
+func ciMode() bool {
+	return *flagDuration != 0 || *flagMaxExecs != 0
+}
+
+// stopReason returns the reason to stop fuzzing (budget set by -duration or -maxexecs
+// is exhausted, or coverage plateaued), or "" if fuzzing should continue.
+func (c *Coordinator) stopReason() string {
+	switch {
+	case *flagDuration != 0 && time.Since(c.startTime) >= *flagDuration:
+		return "fuzzing duration is exhausted"
+	case *flagMaxExecs != 0 && c.statExecs >= *flagMaxExecs:
+		return "fuzzing execs budget is exhausted"
+	case c.checkPlateau():
+		return fmt.Sprintf("no new inputs or coverage for %v", *flagPlateau)
+	}
+	return ""
+}
+
+// finish is called on shutdown: it writes reports and sets process exit status.
+func (c *Coordinator) finish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.flushBuckets()
+	buckets := c.runBuckets()
+	log.Printf("fuzzing finished: execs: %v, duration: %v, crashes: %v, new crashers: %v",
+		c.statExecs, fmtDuration(time.Since(c.startTime)), len(buckets), c.newCrashers)
+	if *flagPlateau != 0 {
+		log.Printf("last progress after %v and %v execs: corpus: %v, cover: %v",
+			fmtDuration(c.lastProgress.Sub(c.startTime)), c.progressExecs, len(c.corpus.m), c.coverFullness)
+	}
+	if *flagJUnit != "" || *flagSARIF != "" {
+		locs := newLocator()
+		if *flagJUnit != "" {
+			writeReport(*flagJUnit, c.junitReport(buckets), "JUnit")
+		}
+		if *flagSARIF != "" {
+			writeReport(*flagSARIF, c.sarifReport(buckets, locs), "SARIF")
+		}
+	}
+	if ciMode() && c.newCrashers != 0 {
+		atomic.StoreInt32(&exitStatus, 1)
+	}
+}
+
+// runBuckets returns buckets of crashes hit during this run sorted by first occurrence.
+func (c *Coordinator) runBuckets() []*crashBucket {
+	var buckets []*crashBucket
+	for _, b := range c.buckets {
+		if len(b.Inputs) != 0 && !b.LastSeen.Before(c.startTime) {
+			buckets = append(buckets, b)
+		}
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].FirstSeen.Before(buckets[j].FirstSeen) })
+	return buckets
+}
+
+func writeReport(fname string, data []byte, typ string) {
+	if err := ioutil.WriteFile(fname, data, 0660); err != nil {
+		log.Printf("failed to write %v report: %v", typ, err)
+	}
+}
+
+// bucketTitle returns short one-line description of the crash.
+func bucketTitle(b *crashBucket) string {
+	msg := b.Message
+	if msg == "" {
+		msg = b.Kind
+	}
+	for _, f := range b.Frames {
+		if !isHarnessFrame(f.Func) {
+			return fmt.Sprintf("%v in %v", msg, f.Func)
+		}
+	}
+	return msg
+}
+
+// isHarnessFrame says if the frame belongs to runtime or go-fuzz rather than to the tested code.
+func isHarnessFrame(fn string) bool {
+	return fn == "panic" || fn == "main.main" || strings.HasPrefix(fn, "runtime.") || strings.HasPrefix(fn, "go-fuzz-dep.")
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Props     []junitProperty `xml:"properties>property"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitReport produces JUnit XML report with a failed test case per crash hit during the run.
+// If there are no crashes, the report contains a single passed test case.
+func (c *Coordinator) junitReport(buckets []*crashBucket) []byte {
+	suite := junitTestSuite{
+		Name:      "go-fuzz",
+		Time:      fmt.Sprintf("%.3f", time.Since(c.startTime).Seconds()),
+		Timestamp: c.startTime.Format(time.RFC3339),
+		Props: []junitProperty{
+			{"execs", fmt.Sprint(c.statExecs)},
+			{"corpus", fmt.Sprint(len(c.corpus.m))},
+			{"cover", fmt.Sprint(c.coverFullness)},
+		},
+	}
+	for _, b := range buckets {
+		var text strings.Builder
+		for _, f := range b.Frames {
+			fmt.Fprintf(&text, "%v\n\t%v:%v\n", f.Func, f.File, f.Line)
+		}
+		fmt.Fprintf(&text, "\ncount: %v\ncrashers: %v\n", b.Count, strings.Join(b.Inputs, " "))
+		if !b.FirstSeen.Before(c.startTime) {
+			fmt.Fprintf(&text, "new in this run\n")
+		}
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      bucketTitle(b),
+			Classname: "go-fuzz." + strings.Replace(b.Kind, " ", "_", -1),
+			Failure: &junitFailure{
+				Message: b.Message,
+				Type:    b.Kind,
+				Text:    text.String(),
+			},
+		})
+	}
+	if len(suite.Cases) == 0 {
+		suite.Cases = append(suite.Cases, junitTestCase{Name: "no crashes", Classname: "go-fuzz"})
+	}
+	suite.Tests = len(suite.Cases)
+	suite.Failures = len(buckets)
+	data, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "\t")
+	if err != nil {
+		panic(err)
+	}
+	return append([]byte(xml.Header), append(data, '\n')...)
+}
+
+// sarifReport produces SARIF 2.1.0 log with a result per crash hit during the run.
+// Rules correspond to crash kinds.
+func (c *Coordinator) sarifReport(buckets []*crashBucket, locs *locator) []byte {
+	type obj = map[string]interface{}
+	rules := []obj{}
+	ruleIdx := make(map[string]int)
+	var results []obj
+	for _, b := range buckets {
+		ruleID := strings.Replace(b.Kind, " ", "-", -1)
+		if _, ok := ruleIdx[ruleID]; !ok {
+			ruleIdx[ruleID] = len(rules)
+			rules = append(rules, obj{
+				"id":               ruleID,
+				"shortDescription": obj{"text": "go-fuzz: " + b.Kind},
+			})
+		}
+		var frames []obj
+		for _, f := range b.Frames {
+			loc := obj{"message": obj{"text": f.Func}}
+			if phys := locs.physicalLocation(f); phys != nil {
+				loc["physicalLocation"] = phys
+			}
+			frames = append(frames, obj{"location": loc})
+		}
+		res := obj{
+			"ruleId":    ruleID,
+			"ruleIndex": ruleIdx[ruleID],
+			"level":     "error",
+			"message":   obj{"text": fmt.Sprintf("%v (crashers: %v)", bucketTitle(b), strings.Join(b.Inputs, ", "))},
+			"partialFingerprints": obj{
+				"goFuzzSignature/v1": hex.EncodeToString(b.sig[:]),
+			},
+			"properties": obj{
+				"count":     b.Count,
+				"firstSeen": b.FirstSeen,
+				"new":       !b.FirstSeen.Before(c.startTime),
+			},
+		}
+		for _, f := range b.Frames {
+			if isHarnessFrame(f.Func) {
+				continue
+			}
+			if phys := locs.physicalLocation(f); phys != nil {
+				res["locations"] = []obj{{"physicalLocation": phys}}
+				break
+			}
+		}
+		if len(frames) != 0 {
+			res["stacks"] = []obj{{"message": obj{"text": b.Message}, "frames": frames}}
+		}
+		results = append(results, res)
+	}
+	if results == nil {
+		results = []obj{}
+	}
+	sarif := obj{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []obj{{
+			"tool": obj{"driver": obj{
+				"name":           "go-fuzz",
+				"informationUri": "https://github.com/dvyukov/go-fuzz",
+				"rules":          rules,
+			}},
+			"results": results,
+		}},
+	}
+	data, err := json.MarshalIndent(sarif, "", "\t")
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// locator maps files in crash stacks to source files using cover blocks from -bin metadata.
+// Stacks refer to copies of source files made by go-fuzz-build, so files are matched
+// by the longest common path suffix.
+type locator struct {
+	blocks map[string][]CoverBlock // source file -> blocks
+	bases  map[string][]string     // file base name -> source files
+	cwd    string
+}
+
+func newLocator() *locator {
+	l := &locator{
+		blocks: make(map[string][]CoverBlock),
+		bases:  make(map[string][]string),
+	}
+	l.cwd, _ = os.Getwd()
+	if *flagBin == "" {
+		return l
+	}
+	metadata, err := readMetadata(*flagBin)
+	if err != nil {
+		log.Printf("failed to read metadata for crash locations: %v", err)
+		return l
+	}
+	for _, b := range metadata.Blocks {
+		if l.blocks[b.File] == nil {
+			base := filepath.Base(b.File)
+			l.bases[base] = append(l.bases[base], b.File)
+		}
+		l.blocks[b.File] = append(l.blocks[b.File], b)
+	}
+	return l
+}
+
+// readMetadata reads metadata from -bin archive.
+func readMetadata(bin string) (MetaData, error) {
+	var metadata MetaData
+	zipr, err := zip.OpenReader(bin)
+	if err != nil {
+		return metadata, err
+	}
+	defer zipr.Close()
+	for _, zipf := range zipr.File {
+		if zipf.Name != "metadata" {
+			continue
+		}
+		r, err := zipf.Open()
+		if err != nil {
+			return metadata, err
+		}
+		defer r.Close()
+		err = json.NewDecoder(r).Decode(&metadata)
+		return metadata, err
+	}
+	return metadata, fmt.Errorf("no metadata in %v", bin)
+}
+
+// sourceFile returns the source file corresponding to the stack file and cover block containing line.
+func (l *locator) sourceFile(file string, line int) (string, *CoverBlock) {
+	best, bestLen := "", 0
+	for _, f := range l.bases[filepath.Base(file)] {
+		if n := commonSuffixLen(f, file); n > bestLen {
+			best, bestLen = f, n
+		}
+	}
+	if best == "" {
+		return "", nil
+	}
+	var blk *CoverBlock
+	for i, b := range l.blocks[best] {
+		if b.StartLine <= line && line <= b.EndLine && (blk == nil || b.EndLine-b.StartLine < blk.EndLine-blk.StartLine) {
+			blk = &l.blocks[best][i]
+		}
+	}
+	return best, blk
+}
+
+// physicalLocation returns SARIF physicalLocation for the frame, or nil if the file is unknown.
+func (l *locator) physicalLocation(f crashFrame) map[string]interface{} {
+	if f.File == "" || f.Line == 0 {
+		return nil
+	}
+	file, blk := l.sourceFile(f.File, f.Line)
+	if file == "" {
+		return nil
+	}
+	region := map[string]interface{}{"startLine": f.Line}
+	if blk != nil && blk.StartLine == f.Line {
+		region["startColumn"] = blk.StartCol
+	}
+	uri := filepath.ToSlash(file)
+	if rel, err := filepath.Rel(l.cwd, file); err == nil && !strings.HasPrefix(rel, "..") {
+		uri = filepath.ToSlash(rel)
+	} else if filepath.IsAbs(file) {
+		uri = "file://" + uri
+	}
+	return map[string]interface{}{
+		"artifactLocation": map[string]interface{}{"uri": uri},
+		"region":           region,
+	}
+}
+
+// commonSuffixLen returns number of common trailing path elements of a and b.
+func commonSuffixLen(a, b string) int {
+	ae := strings.Split(filepath.ToSlash(a), "/")
+	be := strings.Split(filepath.ToSlash(b), "/")
+	n := 0
+	for n < len(ae) && n < len(be) && ae[len(ae)-1-n] == be[len(be)-1-n] {
+		n++
+	}
+	return n
+}
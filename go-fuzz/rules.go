@@ -0,0 +1,128 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"regexp"
+	"time"
+)
+
+const (
+	ruleIgnore = "ignore" // drop matching crashes
+	ruleCount  = "count"  // count matching crashes in buckets, but don't save them
+)
+
+// suppressionRule is a user-defined rule for known and accepted crashes.
+// The rules are stored in a JSON file as a list, for example:
+//
+//	[
+//		{"Action": "ignore", "Message": "^panic: unreachable$", "Frame": "^github\\.com/third/party\\."},
+//		{"Action": "count", "Frame": "^encoding/json\\."}
+//	]
+//
+// A rule matches a crash if all of the specified patterns match.
+type suppressionRule struct {
+	Action  string
+	Message string // regexp matched against crash message
+	Frame   string // regexp matched against function names of the crashing goroutine
+
+	msg   *regexp.Regexp
+	frame *regexp.Regexp
+}
+
+// suppressionRules is a set of suppression rules that is reloaded when the file changes.
+type suppressionRules struct {
+	file    string
+	modTime time.Time
+	rules   []*suppressionRule
+}
+
+func newSuppressionRules(file string) *suppressionRules {
+	sr := &suppressionRules{file: file}
+	if err := sr.reload(); err != nil {
+		log.Fatalf("%v", err)
+	}
+	return sr
+}
+
+// reload re-reads the rules if the file has changed.
+// On error the old rules stay in effect.
+func (sr *suppressionRules) reload() error {
+	fi, err := os.Stat(sr.file)
+	if err != nil {
+		return fmt.Errorf("failed to stat suppression rules: %v", err)
+	}
+	if fi.ModTime().Equal(sr.modTime) {
+		return nil
+	}
+	data, err := ioutil.ReadFile(sr.file)
+	if err != nil {
+		return fmt.Errorf("failed to read suppression rules: %v", err)
+	}
+	rules, err := parseSuppressionRules(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse suppression rules %v: %v", sr.file, err)
+	}
+	if !sr.modTime.IsZero() {
+		log.Printf("reloaded %v suppression rules", len(rules))
+	}
+	sr.modTime = fi.ModTime()
+	sr.rules = rules
+	return nil
+}
+
+func parseSuppressionRules(data []byte) ([]*suppressionRule, error) {
+	var rules []*suppressionRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+	for i, r := range rules {
+		if r.Action != ruleIgnore && r.Action != ruleCount {
+			return nil, fmt.Errorf("rule #%v: unknown action %q", i, r.Action)
+		}
+		if r.Message == "" && r.Frame == "" {
+			return nil, fmt.Errorf("rule #%v: no patterns", i)
+		}
+		var err error
+		if r.Message != "" {
+			if r.msg, err = regexp.Compile(r.Message); err != nil {
+				return nil, fmt.Errorf("rule #%v: %v", i, err)
+			}
+		}
+		if r.Frame != "" {
+			if r.frame, err = regexp.Compile(r.Frame); err != nil {
+				return nil, fmt.Errorf("rule #%v: %v", i, err)
+			}
+		}
+	}
+	return rules, nil
+}
+
+// match returns action of the first rule that matches the crash, or "".
+func (sr *suppressionRules) match(cr *crashReport) string {
+	for _, r := range sr.rules {
+		if r.msg != nil && !r.msg.MatchString(cr.msg) {
+			continue
+		}
+		if r.frame != nil {
+			matched := false
+			for _, f := range cr.frames {
+				if r.frame.MatchString(f.Func) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		return r.Action
+	}
+	return ""
+}
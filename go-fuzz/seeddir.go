@@ -0,0 +1,103 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/dvyukov/go-fuzz/go-fuzz-defs"
+)
+
+// seedSettleTime is how long a file in a seed dir must stay unmodified before it is read,
+// files modified more recently can still be being written by the producer.
+const seedSettleTime = time.Second
+
+// seedDir is an external directory with inputs that is polled during fuzzing (-seeddir).
+type seedDir struct {
+	dir    string
+	files  map[string]seedFile // files that were already read
+	inputs uint64              // new corpus inputs taken from the dir
+}
+
+type seedFile struct {
+	size    int64
+	modTime time.Time
+}
+
+func newSeedDirs(dirs []string) []*seedDir {
+	var res []*seedDir
+	for _, dir := range dirs {
+		res = append(res, &seedDir{
+			dir:   expandHomeDir(dir),
+			files: make(map[string]seedFile),
+		})
+	}
+	return res
+}
+
+// poll returns contents of new and modified files in the dir.
+func (sd *seedDir) poll() [][]byte {
+	var res [][]byte
+	now := time.Now()
+	filepath.Walk(sd.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if !os.IsNotExist(err) {
+				log.Printf("error during seed dir walk: %v", err)
+			}
+			return nil
+		}
+		if strings.HasPrefix(info.Name(), ".") && path != sd.dir {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil // temp or hidden file
+		}
+		if info.IsDir() || now.Sub(info.ModTime()) < seedSettleTime {
+			return nil
+		}
+		f := seedFile{info.Size(), info.ModTime()}
+		if sd.files[path] == f {
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			log.Printf("error during seed file read: %v", err)
+			return nil
+		}
+		sd.files[path] = f
+		if len(data) > MaxInputSize {
+			data = data[:MaxInputSize]
+		}
+		res = append(res, data)
+		return nil
+	})
+	return res
+}
+
+// pollSeedDirs adds new files from seed dirs to corpus and queues them for triage on all workers.
+func (c *Coordinator) pollSeedDirs() {
+	for _, sd := range c.seedDirs {
+		inputs := sd.poll()
+		if len(inputs) == 0 {
+			continue
+		}
+		c.mu.Lock()
+		n := 0
+		for _, data := range inputs {
+			if c.addSeed(data) {
+				n++
+			}
+		}
+		sd.inputs += uint64(n)
+		c.mu.Unlock()
+		if n != 0 {
+			log.Printf("seed dir %v: %v new inputs", sd.dir, n)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/dvyukov/go-fuzz/go-fuzz-defs"
+)
+
+// Sync dir (-syncdir) allows to run go-fuzz in an ensemble with AFL/libFuzzer
+// using AFL's layout:
+//
+//	<syncdir>/<fuzzer>/queue/id:NNNNNN,...
+//
+// Coordinator exports its corpus into <syncdir>/<-syncname>/queue and imports
+// queues of all other fuzzers. Names of imported files are recorded in
+// <syncdir>/<-syncname>/.synced/<fuzzer>, so that every file is imported once.
+type syncDir struct {
+	root     string
+	name     string
+	nextID   int
+	exported map[Sig]bool
+	imported map[string]map[string]bool // fuzzer -> imported file names
+	inputs   map[string]uint64          // new corpus inputs by fuzzer
+	exports  uint64                     // number of exported inputs during this run
+}
+
+func newSyncDir(root, name string) *syncDir {
+	sd := &syncDir{
+		root:     root,
+		name:     name,
+		exported: make(map[Sig]bool),
+		imported: make(map[string]map[string]bool),
+		inputs:   make(map[string]uint64),
+	}
+	if err := os.MkdirAll(sd.queueDir(sd.name), 0770); err != nil {
+		log.Fatalf("failed to create sync dir: %v", err)
+	}
+	if err := os.MkdirAll(sd.syncedDir(), 0770); err != nil {
+		log.Fatalf("failed to create sync dir: %v", err)
+	}
+	files, err := ioutil.ReadDir(sd.queueDir(sd.name))
+	if err != nil {
+		log.Fatalf("failed to read sync dir: %v", err)
+	}
+	for _, f := range files {
+		if id, ok := queueID(f.Name()); ok && id >= sd.nextID {
+			sd.nextID = id + 1
+		}
+		if data, err := ioutil.ReadFile(filepath.Join(sd.queueDir(sd.name), f.Name())); err == nil {
+			sd.exported[hash(data)] = true
+		}
+	}
+	synced, err := ioutil.ReadDir(sd.syncedDir())
+	if err != nil {
+		log.Fatalf("failed to read sync dir: %v", err)
+	}
+	for _, f := range synced {
+		data, err := ioutil.ReadFile(filepath.Join(sd.syncedDir(), f.Name()))
+		if err != nil {
+			log.Printf("failed to read synced file list: %v", err)
+			continue
+		}
+		names := make(map[string]bool)
+		for _, name := range strings.Split(string(data), "\n") {
+			if name != "" {
+				names[name] = true
+			}
+		}
+		sd.imported[f.Name()] = names
+	}
+	return sd
+}
+
+func (sd *syncDir) queueDir(fuzzer string) string {
+	return filepath.Join(sd.root, fuzzer, "queue")
+}
+
+func (sd *syncDir) syncedDir() string {
+	return filepath.Join(sd.root, sd.name, ".synced")
+}
+
+// queueID parses AFL queue file name of the form id:NNNNNN[,...].
+func queueID(name string) (int, bool) {
+	if !strings.HasPrefix(name, "id:") {
+		return 0, false
+	}
+	name = name[len("id:"):]
+	if idx := strings.IndexByte(name, ','); idx != -1 {
+		name = name[:idx]
+	}
+	id, err := strconv.Atoi(name)
+	return id, err == nil
+}
+
+// export writes the input into our queue unless it is already there.
+func (sd *syncDir) export(data []byte) {
+	sig := hash(data)
+	if len(data) == 0 || sd.exported[sig] {
+		return
+	}
+	sd.exported[sig] = true
+	// Other fuzzers can read the queue at any moment, so write the file
+	// outside of the queue and then move it in place.
+	tmp := filepath.Join(sd.root, sd.name, fmt.Sprintf(".tmp.%06d", sd.nextID))
+	fname := filepath.Join(sd.queueDir(sd.name), fmt.Sprintf("id:%06d,src:go-fuzz", sd.nextID))
+	sd.nextID++
+	if err := ioutil.WriteFile(tmp, data, 0660); err != nil {
+		log.Printf("failed to write file: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, fname); err != nil {
+		log.Printf("failed to rename file: %v", err)
+		return
+	}
+	sd.exports++
+}
+
+// poll returns contents of new files in queues of other fuzzers.
+func (sd *syncDir) poll() map[string][][]byte {
+	fuzzers, err := ioutil.ReadDir(sd.root)
+	if err != nil {
+		log.Printf("failed to read sync dir: %v", err)
+		return nil
+	}
+	res := make(map[string][][]byte)
+	now := time.Now()
+	for _, fuzzer := range fuzzers {
+		if !fuzzer.IsDir() || fuzzer.Name() == sd.name || strings.HasPrefix(fuzzer.Name(), ".") {
+			continue
+		}
+		files, err := ioutil.ReadDir(sd.queueDir(fuzzer.Name()))
+		if err != nil {
+			continue // not a fuzzer dir
+		}
+		imported := sd.imported[fuzzer.Name()]
+		if imported == nil {
+			imported = make(map[string]bool)
+			sd.imported[fuzzer.Name()] = imported
+		}
+		var names []string
+		for _, f := range files {
+			if f.IsDir() || strings.HasPrefix(f.Name(), ".") || imported[f.Name()] ||
+				now.Sub(f.ModTime()) < seedSettleTime {
+				continue
+			}
+			data, err := ioutil.ReadFile(filepath.Join(sd.queueDir(fuzzer.Name()), f.Name()))
+			if err != nil {
+				log.Printf("failed to read sync file: %v", err)
+				continue
+			}
+			if len(data) > MaxInputSize {
+				data = data[:MaxInputSize]
+			}
+			imported[f.Name()] = true
+			names = append(names, f.Name())
+			res[fuzzer.Name()] = append(res[fuzzer.Name()], data)
+		}
+		if len(names) != 0 {
+			sd.markImported(fuzzer.Name(), names)
+		}
+	}
+	return res
+}
+
+// markImported appends names of imported files to the synced list of the fuzzer.
+func (sd *syncDir) markImported(fuzzer string, names []string) {
+	f, err := os.OpenFile(filepath.Join(sd.syncedDir(), fuzzer), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0660)
+	if err != nil {
+		log.Printf("failed to open synced file list: %v", err)
+		return
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	for _, name := range names {
+		fmt.Fprintf(w, "%v\n", name)
+	}
+	if err := w.Flush(); err != nil {
+		log.Printf("failed to write synced file list: %v", err)
+	}
+}
+
+// pollSyncDir imports new inputs of other fuzzers and queues them for triage on all workers.
+func (c *Coordinator) pollSyncDir() {
+	if c.syncDir == nil {
+		return
+	}
+	inputs := c.syncDir.poll()
+	var fuzzers []string
+	for fuzzer := range inputs {
+		fuzzers = append(fuzzers, fuzzer)
+	}
+	sort.Strings(fuzzers)
+	for _, fuzzer := range fuzzers {
+		c.mu.Lock()
+		n := 0
+		for _, data := range inputs[fuzzer] {
+			// Don't export it back, it is already in the other fuzzer queue.
+			c.syncDir.exported[hash(data)] = true
+			if c.addSeed(data) {
+				n++
+			}
+		}
+		c.syncDir.inputs[fuzzer] += uint64(n)
+		c.mu.Unlock()
+		if n != 0 {
+			log.Printf("sync dir: %v new inputs from %v", n, fuzzer)
+		}
+	}
+}
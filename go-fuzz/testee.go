@@ -4,6 +4,7 @@
 package main
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -30,12 +31,13 @@ type Testee struct {
 	outPipe     *os.File
 	stdoutPipe  *os.File
 	writebuf    [9]byte  // reusable write buffer
-	resbuf      [24]byte // reusable results buffer
+	resbuf      [32]byte // reusable results buffer
 	startTime   int64
 	execs       int
 	outputC     chan []byte
 	downC       chan bool
 	down        bool
+	exitStatus  int // valid after shutdown
 	fnidx       uint8
 }
 
@@ -69,6 +71,10 @@ func init() {
 // before we start to overwrite old output.
 const testeeBufferSize = 1 << 20
 
+// watchdogGrace is how much time the testee watchdog has to dump
+// goroutine stacks on timeout before we kill the testee ourselves.
+const watchdogGrace = time.Second
+
 func newTestBinary(fileName string, periodicCheck func(), stats *Stats, fnidx uint8) *TestBinary {
 	comm, err := ioutil.TempFile("", "go-fuzz-comm")
 	if err != nil {
@@ -100,7 +106,7 @@ func (bin *TestBinary) close() {
 	os.Remove(bin.commFile)
 }
 
-func (bin *TestBinary) test(data []byte) (res int, ns uint64, cover, sonar, output []byte, crashed, hanged bool) {
+func (bin *TestBinary) test(data []byte) (res int, ns, alloc uint64, cover, sonar, output []byte, crashed, hanged, oom bool) {
 	if len(data) > MaxInputSize {
 		panic("input is too large")
 	}
@@ -115,15 +121,25 @@ func (bin *TestBinary) test(data []byte) (res int, ns uint64, cover, sonar, outp
 			bin.testee = newTestee(bin.fileName, bin.comm, bin.coverRegion, bin.inputRegion, bin.sonarRegion, bin.fnidx, bin.testeeBuffer)
 		}
 		var retry bool
-		res, ns, cover, sonar, crashed, hanged, retry = bin.testee.test(data)
+		res, ns, alloc, cover, sonar, crashed, hanged, retry = bin.testee.test(data)
 		if retry {
 			bin.testee.shutdown()
 			bin.testee = nil
 			continue
 		}
+		if !crashed {
+			bin.stats.noteExecTime(ns)
+		}
 		if crashed {
 			output = bin.testee.shutdown()
-			if hanged {
+			// The testee reports hangs and exceeded memory limit by itself.
+			// The fuzz function can exit with the same status, so check the message too.
+			hanged = hanged || bin.testee.exitStatus == HangExitCode && hasOutputLine(output, "hang: ")
+			oom = bin.testee.exitStatus == MemLimitExitCode && hasOutputLine(output, "out of memory: ")
+			if oom {
+				hdr := fmt.Sprintf("program exceeded memory limit (%v MB)\n\n", *flagMemLimit)
+				output = append([]byte(hdr), output...)
+			} else if hanged {
 				hdr := fmt.Sprintf("program hanged (timeout %v seconds)\n\n", *flagTimeout)
 				output = append([]byte(hdr), output...)
 			}
@@ -134,6 +150,11 @@ func (bin *TestBinary) test(data []byte) (res int, ns uint64, cover, sonar, outp
 	}
 }
 
+// hasOutputLine says if testee output has a line starting with prefix.
+func hasOutputLine(output []byte, prefix string) bool {
+	return bytes.HasPrefix(output, []byte(prefix)) || bytes.Contains(output, []byte("\n"+prefix))
+}
+
 func newTestee(bin string, comm *Mapping, coverRegion, inputRegion, sonarRegion []byte, fnidx uint8, buffer []byte) *Testee {
 retry:
 	rIn, wIn, err := os.Pipe()
@@ -159,6 +180,16 @@ retry:
 	}
 	cmd.Env = append([]string{}, os.Environ()...)
 	cmd.Env = append(cmd.Env, "GOTRACEBACK=1")
+	cmd.Env = append(cmd.Env, fmt.Sprintf("GO_FUZZ_TIMEOUT=%v", int64(time.Duration(*flagTimeout)*time.Second)))
+	if *flagAlloc > 0 {
+		cmd.Env = append(cmd.Env, "GO_FUZZ_ALLOC=1")
+	}
+	if *flagLeaks > 0 {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("GO_FUZZ_LEAKS=%v", int64(*flagLeaks)))
+	}
+	if *flagMemLimit > 0 {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("GO_FUZZ_MEMLIMIT=%v", uint64(*flagMemLimit)<<20))
+	}
 	setupCommMapping(cmd, comm, rOut, wIn)
 	if err = cmd.Start(); err != nil {
 		// This can be a transient failure like "cannot allocate memory" or "text file is busy".
@@ -228,7 +259,7 @@ retry:
 			select {
 			case <-ticker.C:
 				start := atomic.LoadInt64(&t.startTime)
-				if start != 0 && time.Now().UnixNano()-start > int64(timeout) {
+				if start != 0 && time.Now().UnixNano()-start > int64(timeout+watchdogGrace) {
 					atomic.StoreInt64(&t.startTime, -1)
 					t.cmd.Process.Signal(syscall.SIGABRT)
 					time.Sleep(time.Second)
@@ -255,7 +286,7 @@ retry:
 }
 
 // test passes data for testing.
-func (t *Testee) test(data []byte) (res int, ns uint64, cover, sonar []byte, crashed, hanged, retry bool) {
+func (t *Testee) test(data []byte) (res int, ns, alloc uint64, cover, sonar []byte, crashed, hanged, retry bool) {
 	if t.down {
 		log.Fatalf("cannot test: testee is already shutdown")
 	}
@@ -286,12 +317,14 @@ func (t *Testee) test(data []byte) (res int, ns uint64, cover, sonar []byte, cra
 		Res   uint64
 		Ns    uint64
 		Sonar uint64
+		Alloc uint64
 	}
 	_, err := io.ReadFull(t.inPipe, t.resbuf[:])
 	r := Reply{
 		Res:   binary.LittleEndian.Uint64(t.resbuf[:]),
 		Ns:    binary.LittleEndian.Uint64(t.resbuf[8:]),
 		Sonar: binary.LittleEndian.Uint64(t.resbuf[16:]),
+		Alloc: binary.LittleEndian.Uint64(t.resbuf[24:]),
 	}
 	hanged = atomic.LoadInt64(&t.startTime) == -1
 	atomic.StoreInt64(&t.startTime, 0)
@@ -302,6 +335,7 @@ func (t *Testee) test(data []byte) (res int, ns uint64, cover, sonar []byte, cra
 	}
 	res = int(r.Res)
 	ns = r.Ns
+	alloc = r.Alloc
 	cover = t.coverRegion
 	sonar = t.sonarRegion[:r.Sonar]
 	return
@@ -318,6 +352,7 @@ func (t *Testee) shutdown() (output []byte) {
 	if err := t.cmd.Wait(); err != nil {
 		out = append(out, err.Error()...)
 	}
+	t.exitStatus = t.cmd.ProcessState.ExitCode()
 	t.inPipe.Close()
 	t.outPipe.Close()
 	t.stdoutPipe.Close()
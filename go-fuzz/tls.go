@@ -0,0 +1,100 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/rpc"
+)
+
+// Coordinator RPC can be protected with TLS: coordinator presents -tlscert/-tlskey
+// and requires worker certificates signed by -tlsca. Coordinator without -tlsca
+// refuses to start unless -tlsinsecure is given, TLS without client authentication
+// only encrypts the traffic and lets anybody connect.
+// Workers verify coordinator certificate against -tlsca and present -tlscert/-tlskey.
+// Coordinator and worker in the same process (no -coordinator/-worker flags)
+// talk over localhost without TLS. Connection to -upstream coordinator uses
+// the same flags.
+
+func tlsConfigured() bool {
+	return *flagTLSCert != "" || *flagTLSCA != ""
+}
+
+func useTLS() bool {
+	return tlsConfigured() && *flagCoordinator != "localhost:0"
+}
+
+func loadCertPool(file string) *x509.CertPool {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		log.Fatalf("failed to read CA file: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		log.Fatalf("no certificates found in %v", file)
+	}
+	return pool
+}
+
+func loadCert() []tls.Certificate {
+	if *flagTLSCert == "" && *flagTLSKey == "" {
+		return nil
+	}
+	if *flagTLSCert == "" || *flagTLSKey == "" {
+		log.Fatalf("both -tlscert and -tlskey must be specified")
+	}
+	cert, err := tls.LoadX509KeyPair(*flagTLSCert, *flagTLSKey)
+	if err != nil {
+		log.Fatalf("failed to load TLS certificate: %v", err)
+	}
+	return []tls.Certificate{cert}
+}
+
+// coordinatorTLSListener wraps coordinator listener with TLS.
+func coordinatorTLSListener(ln net.Listener) net.Listener {
+	cfg := &tls.Config{
+		Certificates: loadCert(),
+		MinVersion:   tls.VersionTLS12,
+	}
+	if cfg.Certificates == nil {
+		log.Fatalf("coordinator needs -tlscert and -tlskey to use TLS")
+	}
+	if *flagTLSCA != "" {
+		cfg.ClientCAs = loadCertPool(*flagTLSCA)
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	} else if *flagTLSInsecure {
+		log.Printf("-tlsca is not set, workers are not authenticated")
+	} else {
+		log.Fatalf("coordinator needs -tlsca to authenticate workers (use -tlsinsecure to accept any worker)")
+	}
+	return tls.NewListener(ln, cfg)
+}
+
+// dialCoordinatorTLS establishes TLS connection to coordinator.
+func dialCoordinatorTLS(addr string) (*rpc.Client, error) {
+	cfg := &tls.Config{
+		Certificates: loadCert(),
+		ServerName:   *flagTLSServerName,
+		MinVersion:   tls.VersionTLS12,
+	}
+	if *flagTLSCA != "" {
+		cfg.RootCAs = loadCertPool(*flagTLSCA)
+	}
+	if cfg.ServerName == "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ServerName = host
+	}
+	conn, err := tls.Dial("tcp", addr, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return rpc.NewClient(conn), nil
+}
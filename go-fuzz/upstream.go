@@ -0,0 +1,351 @@
+// Copyright 2015 go-fuzz project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/rpc"
+	"sync/atomic"
+	"time"
+)
+
+// Coordinator started with -upstream connects to the upstream coordinator as a worker.
+// New corpus inputs and crashers from the subtree are forwarded upstream,
+// statistics of the whole subtree are reported in Sync, and inputs from upstream
+// are added to the local corpus and relayed to local workers. This allows to build
+// a tree of coordinators (e.g. one per rack) that share the same corpus.
+
+// maxUpstreamInputs is the max number of inputs forwarded upstream per sync.
+const maxUpstreamInputs = 1000
+
+type upstreamLink struct {
+	client *rpc.Client // used only by upstreamLoop, nil when disconnected
+
+	// The rest is protected by Coordinator.mu.
+	id         int
+	caps       capSet
+	paused     bool             // last pause state received from upstream
+	inputs     []NewInputArgs   // new corpus inputs to forward
+	resync     []Sig            // corpus snapshot to forward, see resyncWorker
+	crashers   []NewCrasherArgs // crashers to forward
+	crashes    map[Sig]uint64   // hits of known crashes since the last sync
+	coverStale bool             // corpusCover has changed since the last sync
+	sent       upstreamCounters // counters already reported upstream
+}
+
+// upstreamCounters are cumulative coordinator counters reported upstream as deltas.
+type upstreamCounters struct {
+	execs         uint64
+	restarts      uint64
+	execTypes     [execCount]uint64
+	corpusOrigins [execCount]uint64
+	execTime      [execTimeBuckets]uint64
+	execTimeSum   uint64
+}
+
+func (c *Coordinator) upstreamCounters() upstreamCounters {
+	return upstreamCounters{
+		execs:         c.statExecs,
+		restarts:      c.statRestarts,
+		execTypes:     c.statExecTypes,
+		corpusOrigins: c.statCorpusOrigins,
+		execTime:      c.statExecTime,
+		execTimeSum:   c.statExecTimeSum,
+	}
+}
+
+// subCounters returns a - b.
+func subCounters(a, b []uint64) []uint64 {
+	res := make([]uint64, len(a))
+	for i := range a {
+		res[i] = a[i] - b[i]
+	}
+	return res
+}
+
+func (c *Coordinator) upstreamLoop() {
+	up := c.upstream
+	for range time.NewTicker(syncPeriod).C {
+		if atomic.LoadUint32(&shutdown) != 0 {
+			return
+		}
+		if up.client == nil {
+			if err := c.upstreamConnect(); err != nil {
+				log.Printf("failed to connect to upstream coordinator: %v", err)
+				continue
+			}
+		}
+		if err := c.upstreamSync(); err != nil {
+			log.Printf("upstream sync failed: %v, reconnecting", err)
+			up.client.Close()
+			up.client = nil
+		}
+	}
+}
+
+func (c *Coordinator) upstreamConnect() error {
+	up := c.upstream
+	client, err := dialCoordinator(*flagUpstream, tlsConfigured())
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	args := &ConnectArgs{
+		Procs:      c.procs(),
+		BinHash:    c.binHash,
+		Version:    protocolVersion,
+		MinVersion: minProtocolVersion,
+		Caps:       upstreamCaps(),
+	}
+	c.mu.Unlock()
+	var res ConnectRes
+	if err := client.Call("Coordinator.Connect", args, &res); err != nil {
+		client.Close()
+		return err
+	}
+	if err := checkCoordinatorProtocol(res.Version); err != nil {
+		client.Close()
+		return fmt.Errorf("upstream: %v", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	up.client = client
+	up.id = res.ID
+	up.caps = makeCapSet(res.Caps)
+	up.coverStale = true
+	added := 0
+	upstreamSigs := make(map[Sig]bool)
+	for _, inp := range res.Corpus {
+		upstreamSigs[hash(inp.Data)] = true
+		if c.addUpstreamInput(inp) {
+			added++
+		}
+	}
+	// Upstream may have missed some of our inputs while we were disconnected,
+	// so send it everything it does not have.
+	up.inputs = nil
+	up.resync = nil
+	for _, sig := range corpusSnapshot(c.corpus) {
+		if !upstreamSigs[sig] {
+			up.resync = append(up.resync, sig)
+		}
+	}
+	if up.caps[capControl] {
+		c.setUpstreamPaused(res.Paused)
+	}
+	log.Printf("connected to upstream coordinator %v: got %v new inputs, sending %v inputs",
+		*flagUpstream, added, len(up.resync))
+	return nil
+}
+
+// upstreamCaps returns capabilities for connection to upstream.
+// We need the whole upstream corpus on connect to find out which of our inputs
+// upstream does not have, so we don't ask to stream it. Upstream suppressions
+// are not needed either: crashers are forwarded before local deduplication,
+// and we save and suppress them on our own.
+func upstreamCaps() []string {
+	var res []string
+	for _, c := range capabilities {
+		if c != capStreamCorpus && c != capSuppressions {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// upstreamSync forwards queued inputs and crashers and syncs stats with upstream.
+func (c *Coordinator) upstreamSync() error {
+	up := c.upstream
+	c.mu.Lock()
+	inputs := c.takeUpstreamInputs()
+	crashers := up.crashers
+	up.crashers = nil
+	crashes := up.crashes
+	up.crashes = nil
+	cur := c.upstreamCounters()
+	args := &SyncArgs{
+		ID:            up.id,
+		Procs:         c.procs(),
+		Execs:         cur.execs - up.sent.execs,
+		Restarts:      cur.restarts - up.sent.restarts,
+		CoverFullness: c.coverFullness,
+		Crashes:       crashes,
+	}
+	if up.caps[capExecStats] {
+		args.ExecTypes = subCounters(cur.execTypes[:], up.sent.execTypes[:])
+		args.CorpusOrigins = subCounters(cur.corpusOrigins[:], up.sent.corpusOrigins[:])
+		args.ExecTime = subCounters(cur.execTime[:], up.sent.execTime[:])
+		args.ExecTimeSum = cur.execTimeSum - up.sent.execTimeSum
+		args.SonarSites = c.sonarSites
+		args.SonarSitesHit = c.sonarSitesHit
+	}
+	if up.coverStale && up.caps[capCorpusCover] && c.corpusCover != nil {
+		args.CorpusCover = append([]byte{}, c.corpusCover...)
+		up.coverStale = false
+	}
+	c.mu.Unlock()
+
+	for i := range crashers {
+		crashers[i].ID = up.id
+		if err := up.client.Call("Coordinator.NewCrasher", &crashers[i], nil); err != nil {
+			c.mu.Lock()
+			up.crashers = append(crashers[i:], up.crashers...)
+			c.mu.Unlock()
+			return err
+		}
+	}
+	// Inputs that are lost on error are sent again after reconnect.
+	for i := range inputs {
+		inputs[i].ID = up.id
+		if !up.caps[capInputCover] {
+			inputs[i].Cover = nil
+		}
+		if err := up.client.Call("Coordinator.NewInput", &inputs[i], nil); err != nil {
+			return err
+		}
+	}
+	var res SyncRes
+	if err := up.client.Call("Coordinator.Sync", args, &res); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	up.sent = cur
+	for _, inp := range res.Inputs {
+		c.addUpstreamInput(inp)
+	}
+	for _, sig := range res.Drop {
+		c.dropInput(sig)
+	}
+	if up.caps[capControl] {
+		c.setUpstreamPaused(res.Paused)
+	}
+	return nil
+}
+
+// takeUpstreamInputs returns the next batch of inputs to forward upstream.
+// Must be called with c.mu held.
+func (c *Coordinator) takeUpstreamInputs() []NewInputArgs {
+	up := c.upstream
+	var res []NewInputArgs
+	for len(res) < maxUpstreamInputs {
+		if n := len(up.resync); n != 0 {
+			sig := up.resync[n-1]
+			up.resync = up.resync[:n-1]
+			if a, ok := c.corpus.m[sig]; ok {
+				res = append(res, NewInputArgs{Data: a.data, Prio: a.meta})
+			}
+		} else if len(up.inputs) != 0 {
+			res = append(res, up.inputs[0])
+			up.inputs[0] = NewInputArgs{}
+			up.inputs = up.inputs[1:]
+		} else {
+			break
+		}
+	}
+	if len(up.resync) == 0 {
+		up.resync = nil
+	}
+	if len(up.inputs) == 0 {
+		up.inputs = nil
+	}
+	return res
+}
+
+// forwardInput queues new corpus input for sending upstream.
+// Must be called with c.mu held.
+func (c *Coordinator) forwardInput(a NewInputArgs) {
+	up := c.upstream
+	if up == nil {
+		return
+	}
+	if len(up.inputs) < maxPendingInputs {
+		up.inputs = append(up.inputs, a)
+		return
+	}
+	if up.resync != nil {
+		up.resync = append(up.resync, hash(a.Data))
+		return
+	}
+	log.Printf("upstream coordinator is %v inputs behind, resyncing it with corpus snapshot", len(up.inputs))
+	up.inputs = nil
+	up.resync = corpusSnapshot(c.corpus)
+}
+
+// forwardCrasher queues crasher for sending upstream.
+// Must be called with c.mu held.
+func (c *Coordinator) forwardCrasher(a NewCrasherArgs) {
+	up := c.upstream
+	if up == nil {
+		return
+	}
+	if len(up.crashers) >= maxPendingInputs {
+		if *flagV >= 1 {
+			log.Printf("too many crashers queued for upstream coordinator, dropping crasher")
+		}
+		return
+	}
+	up.crashers = append(up.crashers, a)
+}
+
+// forwardCrashHits accumulates hits of known crashes for reporting upstream.
+// Must be called with c.mu held.
+func (c *Coordinator) forwardCrashHits(hits map[Sig]uint64) {
+	up := c.upstream
+	if up == nil || len(hits) == 0 {
+		return
+	}
+	if up.crashes == nil {
+		up.crashes = make(map[Sig]uint64)
+	}
+	for sig, n := range hits {
+		up.crashes[sig] += n
+	}
+}
+
+// addUpstreamInput adds input received from upstream to corpus and relays it to local workers.
+// Must be called with c.mu held.
+func (c *Coordinator) addUpstreamInput(inp CoordinatorInput) bool {
+	if _, ok := c.dropped[hash(inp.Data)]; ok {
+		return false
+	}
+	if !c.corpus.add(Artifact{inp.Data, inp.Prio, !inp.Minimized}) {
+		return false
+	}
+	c.lastInput = time.Now()
+	c.noteProgress()
+	if c.syncDir != nil {
+		c.syncDir.export(inp.Data)
+	}
+	for _, w := range c.workers {
+		var cover *InputCover
+		if inp.Cover != nil && w.caps[capInputCover] && w.binHash == inp.Cover.BinHash {
+			cover = inp.Cover
+		}
+		c.queueInput(w, CoordinatorInput{inp.Data, inp.Prio, execCorpus, inp.Minimized, inp.Smashed, cover, false})
+	}
+	return true
+}
+
+// setUpstreamPaused applies pause state received from upstream.
+// Local remote control still works between changes of the upstream state.
+// Must be called with c.mu held.
+func (c *Coordinator) setUpstreamPaused(paused bool) {
+	up := c.upstream
+	if up.paused == paused {
+		return
+	}
+	up.paused = paused
+	if paused && !c.paused {
+		log.Printf("fuzzing is paused by upstream coordinator")
+	}
+	if !paused && c.paused {
+		log.Printf("fuzzing is resumed by upstream coordinator")
+		c.resumeTime = time.Now()
+	}
+	c.paused = paused
+}
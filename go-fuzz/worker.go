@@ -51,10 +51,19 @@ type Worker struct {
 
 	triageQueue  []CoordinatorInput
 	crasherQueue []NewCrasherArgs
+	slowQueue    []NewSlowInputArgs
 
 	lastSync time.Time
 	stats    Stats
 	execs    [execCount]uint64
+	synced   [execCount]uint64 // execs sent to hub
+}
+
+// UnstableInput is an input that gives different coverage or result on the same runs.
+type UnstableInput struct {
+	data   []byte
+	blocks []int // toggled cover blocks
+	res    bool  // result differs between runs
 }
 
 type Input struct {
@@ -72,12 +81,33 @@ type Input struct {
 }
 
 func workerMain() {
+	coverBin, sonarBin, metadata, fnidx, cleanup := loadBin()
+	onShutdown(cleanup)
+
+	binData, err := ioutil.ReadFile(*flagBin)
+	if err != nil {
+		log.Fatalf("failed to read bin file: %v", err)
+	}
+	hub := newHub(metadata, hash(binData))
+	for i := 0; i < *flagProcs; i++ {
+		w := &Worker{
+			id:      i,
+			hub:     hub,
+			mutator: newMutator(),
+		}
+		w.coverBin = newTestBinary(coverBin, w.periodicCheck, &w.stats, fnidx)
+		w.sonarBin = newTestBinary(sonarBin, w.periodicCheck, &w.stats, fnidx)
+		go w.loop()
+	}
+}
+
+// loadBin unpacks test binaries from -bin archive into temp files
+// and selects the function to fuzz.
+func loadBin() (coverBin, sonarBin string, metadata MetaData, fnidx uint8, cleanup func()) {
 	zipr, err := zip.OpenReader(*flagBin)
 	if err != nil {
 		log.Fatalf("failed to open bin file: %v", err)
 	}
-	var coverBin, sonarBin string
-	var metadata MetaData
 	for _, zipf := range zipr.File {
 		r, err := zipf.Open()
 		if err != nil {
@@ -118,7 +148,7 @@ func workerMain() {
 		log.Fatalf("bad input archive: missing file")
 	}
 
-	cleanup := func() {
+	cleanup = func() {
 		os.Remove(coverBin)
 		os.Remove(sonarBin)
 	}
@@ -135,35 +165,23 @@ func workerMain() {
 		cleanup()
 		log.Fatalf("-func flag not provided, but multiple fuzz functions available: %v", strings.Join(metadata.Funcs, ", "))
 	}
-	fnidx := -1
+	idx := -1
 	for i, n := range metadata.Funcs {
 		if n == fnname {
-			fnidx = i
+			idx = i
 			break
 		}
 	}
-	if fnidx == -1 {
+	if idx == -1 {
 		cleanup()
 		log.Fatalf("function %v not found, available functions are: %v", fnname, strings.Join(metadata.Funcs, ", "))
 	}
-	if int(uint8(fnidx)) != fnidx {
+	if int(uint8(idx)) != idx {
 		cleanup()
 		log.Fatalf("internal consistency error, please file an issue: too many fuzz functions: %v", metadata.Funcs)
 	}
 
-	shutdownCleanup = append(shutdownCleanup, cleanup)
-
-	hub := newHub(metadata)
-	for i := 0; i < *flagProcs; i++ {
-		w := &Worker{
-			id:      i,
-			hub:     hub,
-			mutator: newMutator(),
-		}
-		w.coverBin = newTestBinary(coverBin, w.periodicCheck, &w.stats, uint8(fnidx))
-		w.sonarBin = newTestBinary(sonarBin, w.periodicCheck, &w.stats, uint8(fnidx))
-		go w.loop()
-	}
+	return coverBin, sonarBin, metadata, uint8(idx), cleanup
 }
 
 func (w *Worker) loop() {
@@ -180,6 +198,23 @@ func (w *Worker) loop() {
 			w.processCrasher(crash)
 			continue
 		}
+		if len(w.slowQueue) > 0 {
+			n := len(w.slowQueue) - 1
+			slow := w.slowQueue[n]
+			w.slowQueue[n] = NewSlowInputArgs{}
+			w.slowQueue = w.slowQueue[:n]
+			if *flagV >= 2 {
+				log.Printf("worker %v processes slow input [%v]%v heavy=%v", w.id, len(slow.Data), hash(slow.Data), slow.Heavy)
+			}
+			w.processSlowInput(slow)
+			continue
+		}
+
+		if atomic.LoadUint32(&w.hub.paused) != 0 {
+			w.periodicCheck()
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
 
 		select {
 		case input := <-w.hub.triageC:
@@ -187,12 +222,7 @@ func (w *Worker) loop() {
 				log.Printf("worker %v triages coordinator input [%v]%v minimized=%v smashed=%v", w.id, len(input.Data), hash(input.Data), input.Minimized, input.Smashed)
 			}
 			w.triageInput(input)
-			for {
-				x := atomic.LoadUint32(&w.hub.initialTriage)
-				if x == 0 || atomic.CompareAndSwapUint32(&w.hub.initialTriage, x, x-1) {
-					break
-				}
-			}
+			w.hub.initialTriageDone()
 			continue
 		default:
 		}
@@ -273,23 +303,33 @@ func (w *Worker) triageInput(input CoordinatorInput) {
 		execTime: 1 << 60,
 	}
 	// Calculate min exec time, min coverage and max result of 3 runs.
+	// Min coverage and result mismatches are used to detect unstable inputs.
+	var minCover []byte
+	resUnstable := false
 	for i := 0; i < 3; i++ {
 		w.execs[execTriageInput]++
-		res, ns, cover, _, output, crashed, hanged := w.coverBin.test(inp.data)
+		res, ns, _, cover, _, output, crashed, hanged, oom := w.coverBin.test(inp.data)
 		if crashed {
 			// Inputs in corpus should not crash.
-			w.noteCrasher(inp.data, output, hanged)
+			w.noteCrasher(inp.data, output, hanged, oom)
 			return
 		}
 		if inp.cover == nil {
 			inp.cover = make([]byte, CoverSize)
 			copy(inp.cover, cover)
+			minCover = makeCopy(cover)
 		} else {
 			for i, v := range cover {
 				x := inp.cover[i]
 				if v > x {
 					inp.cover[i] = v
 				}
+				if v < minCover[i] {
+					minCover[i] = v
+				}
+			}
+			if inp.res != res {
+				resUnstable = true
 			}
 		}
 		if inp.res < res {
@@ -299,19 +339,41 @@ func (w *Worker) triageInput(input CoordinatorInput) {
 			inp.execTime = ns
 		}
 	}
+	// Blocks toggled between runs are flaky, they are not a reason to consider the input new.
+	var flaky []int
+	for i, v := range inp.cover {
+		if roundUpCover(v) != roundUpCover(minCover[i]) {
+			flaky = append(flaky, i)
+		}
+	}
+	if len(flaky) != 0 || resUnstable {
+		w.hub.newUnstableC <- UnstableInput{makeCopy(inp.data), flaky, resUnstable}
+	}
 	if !input.Minimized {
 		inp.mine = true
 		ro := w.hub.ro.Load().(*ROData)
 		// When minimizing new inputs we don't pursue exactly the same coverage,
 		// instead we pursue just the "novelty" in coverage.
 		// Here we use corpusCover, because maxCover already includes the input coverage.
-		newCover, ok := findNewCover(ro.corpusCover, inp.cover)
+		newCover, ok := findNewCover(ro.corpusCover, inp.cover, ro.flaky)
+		if ok && len(flaky) != 0 {
+			for _, i := range flaky {
+				newCover[i] = 0
+			}
+			ok = false
+			for _, v := range newCover {
+				if v != 0 {
+					ok = true
+					break
+				}
+			}
+		}
 		if !ok {
 			return // covered by somebody else
 		}
-		inp.data = w.minimizeInput(inp.data, false, func(candidate, cover, output []byte, res int, crashed, hanged bool) bool {
+		inp.data = w.minimizeInput(inp.data, false, func(candidate, cover, output []byte, res int, ns, alloc uint64, crashed, hanged, oom bool) bool {
 			if crashed {
-				w.noteCrasher(candidate, output, hanged)
+				w.noteCrasher(candidate, output, hanged, oom)
 				return false
 			}
 			if inp.res != res || worseCover(newCover, cover) {
@@ -334,15 +396,19 @@ func (w *Worker) triageInput(input CoordinatorInput) {
 
 // processCrasher minimizes new crashers and sends them to the hub.
 func (w *Worker) processCrasher(crash NewCrasherArgs) {
+	// The crash may have become known while it was queued.
+	if w.knownCrash(crash.Suppression) {
+		return
+	}
 	// Hanging inputs can take very long time to minimize.
 	if !crash.Hanging {
-		crash.Data = w.minimizeInput(crash.Data, true, func(candidate, cover, output []byte, res int, crashed, hanged bool) bool {
+		crash.Data = w.minimizeInput(crash.Data, true, func(candidate, cover, output []byte, res int, ns, alloc uint64, crashed, hanged, oom bool) bool {
 			if !crashed {
 				return false
 			}
-			supp := extractSuppression(output)
-			if hanged || !bytes.Equal(crash.Suppression, supp) {
-				w.noteCrasher(candidate, output, hanged)
+			supp := extractSuppression(output, w.hub.dedup)
+			if hanged || oom != crash.OutOfMemory || !bytes.Equal(crash.Suppression, supp) {
+				w.noteCrasher(candidate, output, hanged, oom)
 				return false
 			}
 			crash.Error = output
@@ -352,9 +418,33 @@ func (w *Worker) processCrasher(crash NewCrasherArgs) {
 	w.hub.newCrasherC <- crash
 }
 
+// processSlowInput minimizes new slow or allocation-heavy inputs and sends them to the hub.
+// Minimization preserves the excess over the -slow or -alloc threshold.
+func (w *Worker) processSlowInput(slow NewSlowInputArgs) {
+	slow.Data = w.minimizeInput(slow.Data, false, func(candidate, cover, output []byte, res int, ns, alloc uint64, crashed, hanged, oom bool) bool {
+		if crashed {
+			w.noteCrasher(candidate, output, hanged, oom)
+			return false
+		}
+		if slow.Heavy {
+			if alloc <= uint64(*flagAlloc)<<20 {
+				return false
+			}
+			slow.Alloc = alloc
+		} else {
+			if ns <= uint64(*flagSlow) {
+				return false
+			}
+			slow.Ns = ns
+		}
+		return true
+	})
+	w.hub.newSlowC <- slow
+}
+
 // minimizeInput applies series of minimizing transformations to data
 // and asks pred whether the input is equivalent to the original one or not.
-func (w *Worker) minimizeInput(data []byte, canonicalize bool, pred func(candidate, cover, output []byte, result int, crashed, hanged bool) bool) []byte {
+func (w *Worker) minimizeInput(data []byte, canonicalize bool, pred func(candidate, cover, output []byte, result int, ns, alloc uint64, crashed, hanged, oom bool) bool) []byte {
 	res := make([]byte, len(data))
 	copy(res, data)
 	start := time.Now()
@@ -371,8 +461,8 @@ func (w *Worker) minimizeInput(data []byte, canonicalize bool, pred func(candida
 			}
 			candidate := res[:len(res)-n]
 			*stat++
-			result, _, cover, _, output, crashed, hanged := w.coverBin.test(candidate)
-			if !pred(candidate, cover, output, result, crashed, hanged) {
+			result, ns, alloc, cover, _, output, crashed, hanged, oom := w.coverBin.test(candidate)
+			if !pred(candidate, cover, output, result, ns, alloc, crashed, hanged, oom) {
 				break
 			}
 			res = candidate
@@ -389,8 +479,8 @@ func (w *Worker) minimizeInput(data []byte, canonicalize bool, pred func(candida
 		copy(candidate[:i], res[:i])
 		copy(candidate[i:], res[i+1:])
 		*stat++
-		result, _, cover, _, output, crashed, hanged := w.coverBin.test(candidate)
-		if !pred(candidate, cover, output, result, crashed, hanged) {
+		result, ns, alloc, cover, _, output, crashed, hanged, oom := w.coverBin.test(candidate)
+		if !pred(candidate, cover, output, result, ns, alloc, crashed, hanged, oom) {
 			continue
 		}
 		res = makeCopy(candidate)
@@ -407,8 +497,8 @@ func (w *Worker) minimizeInput(data []byte, canonicalize bool, pred func(candida
 			candidate := tmp[:len(res)-j+i]
 			copy(candidate[i:], res[j:])
 			*stat++
-			result, _, cover, _, output, crashed, hanged := w.coverBin.test(candidate)
-			if !pred(candidate, cover, output, result, crashed, hanged) {
+			result, ns, alloc, cover, _, output, crashed, hanged, oom := w.coverBin.test(candidate)
+			if !pred(candidate, cover, output, result, ns, alloc, crashed, hanged, oom) {
 				continue
 			}
 			res = makeCopy(candidate)
@@ -429,8 +519,8 @@ func (w *Worker) minimizeInput(data []byte, canonicalize bool, pred func(candida
 			copy(candidate, res)
 			candidate[i] = '0'
 			*stat++
-			result, _, cover, _, output, crashed, hanged := w.coverBin.test(candidate)
-			if !pred(candidate, cover, output, result, crashed, hanged) {
+			result, ns, alloc, cover, _, output, crashed, hanged, oom := w.coverBin.test(candidate)
+			if !pred(candidate, cover, output, result, ns, alloc, crashed, hanged, oom) {
 				continue
 			}
 			res = makeCopy(candidate)
@@ -603,11 +693,15 @@ func (w *Worker) testInputImpl(bin *TestBinary, data []byte, depth int, typ exec
 		}
 	}
 	w.execs[typ]++
-	res, _, cover, sonar, output, crashed, hanged := bin.test(data)
+	res, ns, alloc, cover, sonar, output, crashed, hanged, oom := bin.test(data)
 	if crashed {
-		w.noteCrasher(data, output, hanged)
+		w.noteCrasher(data, output, hanged, oom)
 		return nil
 	}
+	if bin == w.coverBin {
+		// Sonar binary is considerably slower, so don't judge execution time by it.
+		w.noteSlowInput(data, cover, ns, alloc)
+	}
 	w.noteNewInput(data, cover, res, depth, typ)
 	return sonar
 }
@@ -618,14 +712,44 @@ func (w *Worker) noteNewInput(data, cover []byte, res, depth int, typ execType)
 		return
 	}
 	if w.hub.updateMaxCover(cover) {
-		w.triageQueue = append(w.triageQueue, CoordinatorInput{makeCopy(data), uint64(depth), typ, false, false})
+		w.triageQueue = append(w.triageQueue, CoordinatorInput{makeCopy(data), uint64(depth), typ, false, false, nil, false})
+	}
+}
+
+// noteSlowInput queues inputs that exceed -slow or -alloc thresholds for minimization.
+func (w *Worker) noteSlowInput(data, cover []byte, ns, alloc uint64) {
+	if *flagSlow > 0 && ns > uint64(*flagSlow) && w.hub.updateSlowCover(false, cover) {
+		w.slowQueue = append(w.slowQueue, NewSlowInputArgs{
+			Data: makeCopy(data),
+			Ns:   ns,
+		})
+	}
+	if *flagAlloc > 0 && alloc > uint64(*flagAlloc)<<20 && w.hub.updateSlowCover(true, cover) {
+		w.slowQueue = append(w.slowQueue, NewSlowInputArgs{
+			Data:  makeCopy(data),
+			Alloc: alloc,
+			Heavy: true,
+		})
 	}
 }
 
-func (w *Worker) noteCrasher(data, output []byte, hanged bool) {
+// knownCrash counts a hit of the crash and returns true if the crash is already known.
+func (w *Worker) knownCrash(supp []byte) bool {
 	ro := w.hub.ro.Load().(*ROData)
-	supp := extractSuppression(output)
-	if _, ok := ro.suppressions[hash(supp)]; ok {
+	sig := hash(supp)
+	if _, ok := ro.suppressions[sig]; !ok {
+		return false
+	}
+	if w.stats.crashes == nil {
+		w.stats.crashes = make(map[Sig]uint64)
+	}
+	w.stats.crashes[sig]++
+	return true
+}
+
+func (w *Worker) noteCrasher(data, output []byte, hanged, oom bool) {
+	supp := extractSuppression(output, w.hub.dedup)
+	if w.knownCrash(supp) {
 		return
 	}
 	w.crasherQueue = append(w.crasherQueue, NewCrasherArgs{
@@ -633,6 +757,7 @@ func (w *Worker) noteCrasher(data, output []byte, hanged bool) {
 		Error:       output,
 		Suppression: supp,
 		Hanging:     hanged,
+		OutOfMemory: oom,
 	})
 }
 
@@ -645,10 +770,13 @@ func (w *Worker) periodicCheck() {
 		return
 	}
 	w.execs[execTotal] += w.stats.execs
+	for i, n := range w.execs {
+		w.stats.execTypes[i] = n - w.synced[i]
+	}
+	w.synced = w.execs
 	w.lastSync = time.Now()
 	w.hub.syncC <- w.stats
-	w.stats.execs = 0
-	w.stats.restarts = 0
+	w.stats = Stats{}
 	if *flagV >= 2 {
 		log.Printf("worker %v: triageq=%v execs=%v mininp=%v mincrash=%v triage=%v fuzz=%v versifier=%v smash=%v sonar=%v hint=%v",
 			w.id, len(w.triageQueue),
@@ -664,13 +792,42 @@ func (w *Worker) shutdown() {
 	w.sonarBin.close()
 }
 
-func extractSuppression(out []byte) []byte {
+func extractSuppression(out []byte, ds dedupStrategy) []byte {
 	var supp []byte
 	seenPanic := false
 	collect := false
+	oom := false
 	s := bufio.NewScanner(bytes.NewReader(out))
 	for s.Scan() {
 		line := s.Text()
+		if !seenPanic && strings.HasPrefix(line, "program exceeded memory limit") {
+			// Testee exceeded -memlimit, the first stack that follows
+			// is the stack of the goroutine running the fuzz function.
+			seenPanic = true
+			oom = true
+			supp = append(supp, "out of memory\n"...)
+			continue
+		}
+		if !seenPanic && strings.HasPrefix(line, "goroutine leak: ") {
+			// Goroutines left running by the fuzz function, the stack that follows
+			// is the stack of the first leaked goroutine. Don't include the number
+			// of leaked goroutines, so that each leak site is reported once.
+			seenPanic = true
+			supp = append(supp, "goroutine leak\n"...)
+			continue
+		}
+		if !seenPanic && strings.HasPrefix(line, "hang: ") {
+			// Hang detected by the testee watchdog, the stack that follows
+			// is the stack of the goroutine running the fuzz function.
+			seenPanic = true
+			supp = append(supp, "hang\n"...)
+			continue
+		}
+		if oom && !collect {
+			// Skip the testee message up to the first goroutine stack.
+			collect = strings.HasPrefix(line, "goroutine ")
+			continue
+		}
 		if !seenPanic && (strings.HasPrefix(line, "panic: ") ||
 			strings.HasPrefix(line, "fatal error: ") ||
 			strings.HasPrefix(line, "SIG") && strings.Index(line, ": ") != 0) {
@@ -679,7 +836,7 @@ func extractSuppression(out []byte) []byte {
 			supp = append(supp, line...)
 			supp = append(supp, '\n')
 			if line == "SIGABRT: abort" || line == "signal: killed" {
-				return supp // timeout stacks are flaky
+				return ds.apply(supp) // timeout stacks are flaky
 			}
 		}
 		if collect && line == "runtime stack:" {
@@ -688,8 +845,8 @@ func extractSuppression(out []byte) []byte {
 			collect = false
 		}
 		if collect && len(line) > 0 && (line[0] >= 'a' && line[0] <= 'z' ||
-			line[0] >= 'A' && line[0] <= 'Z') {
-			// Function name line.
+			line[0] >= 'A' && line[0] <= 'Z') && !strings.HasPrefix(line, "goroutine ") {
+			// Function name line (goroutine header can contain parens, e.g. "[select (no cases)]").
 			idx := strings.LastIndex(line, "(")
 			if idx != -1 {
 				supp = append(supp, line[:idx]...)
@@ -706,9 +863,9 @@ func extractSuppression(out []byte) []byte {
 		}
 	}
 	if len(supp) == 0 {
-		supp = out
+		return out
 	}
-	return supp
+	return ds.apply(supp)
 }
 
 func reverse(data []byte) []byte {